@@ -1,63 +1,468 @@
 package main
 
 import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/database"
+	"Go-IssueTracker-API/internal/eventbus"
+	"Go-IssueTracker-API/internal/grpcapi"
 	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/idempotency"
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/mail"
+	"Go-IssueTracker-API/internal/metrics"
+	"Go-IssueTracker-API/internal/presence"
+	"Go-IssueTracker-API/internal/ratelimit"
 	"Go-IssueTracker-API/internal/repository"
 	"Go-IssueTracker-API/internal/service"
+	"Go-IssueTracker-API/internal/storage"
+	"Go-IssueTracker-API/internal/tlsconfig"
+	"Go-IssueTracker-API/internal/tracing"
+	"Go-IssueTracker-API/internal/version"
+	"Go-IssueTracker-API/internal/webhook"
 
-	"database/sql"
-	"log"
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"net"
 	"net/http"
 	"fmt"
+	"time"
 
-	"github.com/go-chi/chi/v5"
-	_ "github.com/lib/pq"
 	"Go-IssueTracker-API/internal/config"
+
+	"google.golang.org/grpc"
 )
 
 func main() {
-	// init config
-	cfg, err := config.LoadConfig("config.yaml")
+	// init config: file, then ISSUETRACKER_* environment overrides
+	configPath := flag.String("config", envOr("ISSUETRACKER_CONFIG", "config.yaml"), "path to the config file, empty for defaults and environment only")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(*configPath)
 	if err != nil {
-		log.Fatal(err)
+		fatal("Cannot load config", err)
 	}
 
-	// init database: postgres
-	connStr := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		cfg.Storage.Postgres.User,
-		cfg.Storage.Postgres.Password,
-		cfg.Storage.Postgres.Host,
-		cfg.Storage.Postgres.Port, 
-		cfg.Storage.Postgres.Database,
-	)
+	// init logging: JSON records, the standard log package writes through it as well
+	logger, err := logging.New(os.Stdout, cfg.Log.Level)
+	if err != nil {
+		fatal("Cannot init logging", err)
+	}
+	slog.SetDefault(logger)
 
+	// init tracing: W3C trace context in, spans out to OTLP or the console
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		fatal("Cannot init tracing", err)
+	}
 
-	db, err := sql.Open("postgres", connStr)
+	// init database: postgres, waiting for it to come up
+	pg := cfg.Storage.Postgres
+	db, err := database.Open(context.Background(), database.Config{
+		Host:             pg.Host,
+		Port:             pg.Port,
+		Database:         pg.Database,
+		User:             pg.User,
+		Password:         pg.Password,
+		SSLMode:          pg.SSLMode,
+		MaxOpenConns:     pg.MaxOpenConns,
+		MaxIdleConns:     pg.MaxIdleConns,
+		ConnMaxLifetime:  pg.ConnMaxLifetime,
+		ConnMaxIdleTime:  pg.ConnMaxIdleTime,
+		StatementTimeout: pg.StatementTimeout,
+		StartupTimeout:   pg.StartupTimeout,
+		StartupBackoff:   pg.StartupBackoff,
+	})
 	if err != nil {
-		log.Fatal(err)
+		fatal("Cannot connect to database", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		log.Fatal("Cannot connect to database:", err)
+	// init blob storage for attachments
+	blobs, err := newBlobStore(cfg)
+	if err != nil {
+		fatal("Cannot init attachment storage", err)
 	}
 
 	// create repository, service and handler
 	repo := repository.NewPostgresIssueRepository(db)
-	svc := service.NewIssueService(repo)
+	fieldRepo := repository.NewPostgresFieldRepository(db)
+	watcherRepo := repository.NewPostgresWatcherRepository(db)
+	webhookRepo := repository.NewPostgresWebhookRepository(db)
+	memberSvc, err := service.NewMemberService(repository.NewPostgresMemberRepository(db), cfg.Auth.DefaultRole, cfg.Auth.Admins)
+	if err != nil {
+		fatal("Cannot init access control", err)
+	}
+	// roles need a known caller, so they are only enforced with authentication
+	var fieldOpts []service.FieldOption
+	var webhookOpts []service.WebhookOption
+	if cfg.Auth.Enabled {
+		fieldOpts = append(fieldOpts, service.WithFieldAuthorizer(memberSvc))
+		webhookOpts = append(webhookOpts, service.WithWebhookAuthorizer(memberSvc))
+	}
+	webhookSvc := service.NewWebhookService(webhookRepo, webhookOpts...)
+	notificationRepo := repository.NewPostgresNotificationRepository(db)
+	notificationSvc := service.NewNotificationService(notificationRepo, repo)
+
+	// streaming clients follow changes through the in-process event bus
+	bus := eventbus.New(eventbus.DefaultBuffer, eventbus.DefaultHistory)
+
+	opts := []service.Option{
+		service.WithFieldRepository(fieldRepo),
+		service.WithWatcherRepository(watcherRepo),
+		service.WithEventPublisher(webhookSvc),
+		service.WithEventPublisher(bus),
+	}
+
+	// init metrics: HTTP requests, connection pool and issue events
+	var m *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		m = metrics.New()
+		if err := m.RegisterDB(db, cfg.Storage.Postgres.Database); err != nil {
+			fatal("Cannot init metrics", err)
+		}
+		opts = append(opts, service.WithEventPublisher(m))
+	}
+	if cfg.Notifications.Enabled {
+		opts = append(opts, service.WithEventPublisher(notificationSvc))
+	}
+	if cfg.Auth.Enabled {
+		opts = append(opts, service.WithAuthorizer(memberSvc))
+	}
+	attachmentRepo := repository.NewPostgresAttachmentRepository(db)
+	opts = append(opts, service.WithAttachments(attachmentRepo, blobs))
+	svc := service.NewIssueService(repo, opts...)
+	fieldSvc := service.NewFieldService(fieldRepo, fieldOpts...)
+	attachmentSvc := service.NewAttachmentService(attachmentRepo, repo, blobs)
+
+	// init rate limits per route group
+	rateLimit, err := newRateLimits(cfg)
+	if err != nil {
+		fatal("Cannot init rate limits", err)
+	}
+
 	h := handler.NewHandler(svc)
+	fh := handler.NewFieldHandler(fieldSvc)
+	ah := handler.NewAttachmentHandler(attachmentSvc, cfg.Attachments.MaxSize)
+	wh := handler.NewWatcherHandler(service.NewWatcherService(watcherRepo, repo))
+	hh := handler.NewWebhookHandler(webhookSvc)
+	nh := handler.NewNotificationHandler(notificationSvc)
+	tokenSvc := service.NewTokenService(repository.NewPostgresTokenRepository(db))
+	th := handler.NewTokenHandler(tokenSvc)
+	mh := handler.NewMemberHandler(memberSvc)
+	gh := handler.NewGraphQLHandler(svc, fieldSvc, attachmentSvc, notificationSvc, rateLimit.check("read"), rateLimit.check("write")) // queries count as reads, mutations as writes
+	sh := handler.NewStreamHandler(bus, 15*time.Second) // heartbeats keep proxies from closing idle streams
+	wsh := handler.NewWebSocketHandler(bus, presence.New(), 30*time.Second)
+	healthH := handler.NewHealthHandler(db)
+
+	// start background workers, they stop once the server has drained its requests
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	webhookWorker := webhook.NewWorker(webhookRepo, webhook.Config{
+		PollInterval: cfg.Webhooks.PollInterval,
+		BatchSize:    cfg.Webhooks.BatchSize,
+		Timeout:      cfg.Webhooks.Timeout,
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		BaseBackoff:  cfg.Webhooks.BaseBackoff,
+		MaxBackoff:   cfg.Webhooks.MaxBackoff,
+	})
+	workers.Go(func() { webhookWorker.Run(workerCtx) })
+
+	if cfg.Notifications.Enabled {
+		mailWorker, err := newMailWorker(cfg, notificationRepo)
+		if err != nil {
+			fatal("Cannot init email notifications", err)
+		}
+		workers.Go(func() { mailWorker.Run(workerCtx) })
+	}
 	
+	// init authentication
+	var authenticate func(http.Handler) http.Handler
+	var authenticateRPC func(context.Context, string) (context.Context, error)
+	if cfg.Auth.Enabled {
+		verifier, err := newVerifier(cfg)
+		if err != nil {
+			fatal("Cannot init authentication", err)
+		}
+		authenticate = auth.Middleware(verifier, tokenSvc)
+		authenticateRPC = func(ctx context.Context, token string) (context.Context, error) {
+			return auth.Authenticate(ctx, verifier, tokenSvc, token)
+		}
+	} else {
+		slog.Warn("Authentication is disabled, all requests are anonymous")
+		authenticate = func(next http.Handler) http.Handler { return next }
+	}
+
+	// retried POST requests with an Idempotency-Key get the first response;
+	// uploads are too large to buffer and created tokens must not be stored in plain text
+	idempotent := idempotency.Middleware(repository.NewPostgresIdempotencyRepository(db), cfg.Idempotency.TTL)
+
 	// init router: chi
-	r := chi.NewRouter()
-	r.Post("/issues", h.CreateIssue)
-	r.Get("/issues/{id}", h.GetIssueByID)
-	r.Put("/issues/{id}", h.UpdateIssue)
-	r.Delete("/issues/{id}", h.DeleteIssue)
-	r.Get("/issues", h.ListIssues)
-
-	// run server
-	addr := fmt.Sprintf(":%d", cfg.Server.Port)
-	
-	log.Printf("Server running on %s\n", addr)
-	log.Fatal(http.ListenAndServe(addr, r))
-}
\ No newline at end of file
+	r := newRouter(routes{
+		issues:        h,
+		fields:        fh,
+		attachments:   ah,
+		watchers:      wh,
+		webhooks:      hh,
+		notifications: nh,
+		tokens:        th,
+		members:       mh,
+		graphql:       gh,
+		stream:        sh,
+		websocket:     wsh,
+		health:        healthH,
+		metrics:       m,
+		docs:          cfg.Docs.Enabled,
+		logger:        logger,
+		authenticate:  authenticate,
+		rateLimit:     rateLimit.middleware,
+		idempotent:    idempotent,
+	})
+
+	// run server until SIGINT or SIGTERM
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           r,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+	}
+
+	// serve HTTPS when a certificate is configured, reloading it when the files change
+	tlsCfg := cfg.Server.TLS
+	if tlsCfg.CertFile != "" {
+		serverTLS, reloader, err := tlsconfig.New(tlsconfig.Config{
+			CertFile:     tlsCfg.CertFile,
+			KeyFile:      tlsCfg.KeyFile,
+			ClientCAFile: tlsCfg.ClientCAFile,
+			ClientAuth:   tlsCfg.ClientAuth,
+			MinVersion:   tlsCfg.MinVersion,
+		})
+		if err != nil {
+			fatal("Cannot init TLS", err)
+		}
+		srv.TLSConfig = serverTLS
+		workers.Go(func() { reloader.Watch(workerCtx, tlsCfg.ReloadInterval) })
+	}
+
+	// serve gRPC on its own port, with the same credentials and certificate
+	var grpcSrv *grpc.Server
+	if cfg.GRPC.Enabled {
+		grpcSrv = grpcapi.NewServer(svc, bus, grpcapi.Config{
+			Authenticate: authenticateRPC,
+			TLS:          srv.TLSConfig,
+			Logger:       logger,
+			RateLimits:   rateLimit,
+		})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 2)
+	if grpcSrv != nil {
+		ln, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+		if err != nil {
+			fatal("Cannot listen for gRPC", err)
+		}
+		go func() {
+			slog.Info("gRPC server running", "addr", ln.Addr().String(), "tls", srv.TLSConfig != nil)
+			serverErr <- grpcSrv.Serve(ln)
+		}()
+	}
+	go func() {
+		slog.Info("Server running", "addr", srv.Addr, "tls", srv.TLSConfig != nil, "version", version.Version)
+		if srv.TLSConfig != nil {
+			// the certificate comes from TLSConfig.GetCertificate
+			serverErr <- srv.ListenAndServeTLS("", "")
+		} else {
+			serverErr <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
+		fatal("Server stopped", err)
+	case <-ctx.Done():
+	}
+	// a second signal kills the process without waiting for the drain
+	stop()
+
+	// stop accepting connections and wait for in-flight requests, then for the workers
+	slog.Info("Shutting down", "timeout", cfg.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	// streams end when the bus closes, so the gRPC server can drain next to the HTTP server
+	bus.Close()
+	grpcStopped := make(chan struct{})
+	go func() {
+		if grpcSrv != nil {
+			grpcSrv.GracefulStop()
+		}
+		close(grpcStopped)
+	}()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Cannot drain requests before the deadline", "error", err)
+	}
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		if grpcSrv != nil {
+			slog.Error("Cannot drain gRPC calls before the deadline")
+			grpcSrv.Stop()
+		}
+	}
+	stopWorkers()
+	workers.Wait()
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Cannot flush traces", "error", err)
+	}
+	if err := db.Close(); err != nil {
+		slog.Error("Cannot close database", "error", err)
+	}
+	slog.Info("Server stopped")
+}
+
+// envOr returns the environment variable key, or fallback when it is not set.
+func envOr(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// fatal logs err and exits, like log.Fatal.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+
+func newBlobStore(cfg *config.Config) (service.BlobStore, error) {
+	switch cfg.Attachments.Storage {
+	case "local":
+		return storage.NewLocalStore(cfg.Attachments.Local.Dir)
+	case "s3":
+		s3 := cfg.Attachments.S3
+		return storage.NewS3Store(context.Background(), storage.S3Config{
+			Endpoint:  s3.Endpoint,
+			Region:    s3.Region,
+			Bucket:    s3.Bucket,
+			AccessKey: s3.AccessKey,
+			SecretKey: s3.SecretKey,
+			UseSSL:    s3.UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown attachment storage %q", cfg.Attachments.Storage)
+	}
+}
+
+func newMailWorker(cfg *config.Config, store mail.Store) (*mail.Worker, error) {
+	smtpCfg := cfg.Notifications.SMTP
+	sender, err := mail.NewSMTPSender(mail.SMTPConfig{
+		Host:     smtpCfg.Host,
+		Port:     smtpCfg.Port,
+		Username: smtpCfg.Username,
+		Password: smtpCfg.Password,
+		From:     smtpCfg.From,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	renderer, err := mail.NewRenderer()
+	if err != nil {
+		return nil, err
+	}
+
+	return mail.NewWorker(store, sender, renderer, mail.WorkerConfig{
+		PollInterval:   cfg.Notifications.PollInterval,
+		DigestInterval: cfg.Notifications.DigestInterval,
+		BatchSize:      cfg.Notifications.BatchSize,
+		MaxAttempts:    cfg.Notifications.MaxAttempts,
+		BaseBackoff:    cfg.Notifications.BaseBackoff,
+		MaxBackoff:     cfg.Notifications.MaxBackoff,
+		SendTimeout:    smtpCfg.Timeout,
+	}), nil
+}
+
+// rateLimits holds the limiters of the route groups configured under rate_limit.groups.
+// Groups without a limit are not limited.
+type rateLimits map[string]*ratelimit.Limiter
+
+func newRateLimits(cfg *config.Config) (rateLimits, error) {
+	limiters := make(rateLimits)
+	if cfg.RateLimit.Enabled {
+		for group, limit := range cfg.RateLimit.Groups {
+			if limit.Requests <= 0 || limit.Per <= 0 {
+				return nil, fmt.Errorf("rate limit %q: requests and per must be positive", group)
+			}
+			limiters[group] = ratelimit.New(ratelimit.Limit{
+				Requests: limit.Requests,
+				Per:      limit.Per,
+				Burst:    limit.Burst,
+			}, ratelimit.UserOrIP)
+		}
+	}
+
+	return limiters, nil
+}
+
+// middleware limits the routes of the group.
+func (l rateLimits) middleware(group string) func(http.Handler) http.Handler {
+	if limiter, ok := l[group]; ok {
+		return limiter.Middleware
+	}
+	return func(next http.Handler) http.Handler { return next }
+}
+
+// check limits requests of the group that are only recognized by their handler, nil when it is not limited.
+func (l rateLimits) check(group string) func(*http.Request) error {
+	if limiter, ok := l[group]; ok {
+		return limiter.Check
+	}
+	return nil
+}
+
+func newVerifier(cfg *config.Config) (*auth.Verifier, error) {
+	jwtCfg := auth.JWTConfig{
+		Issuer:      cfg.Auth.Issuer,
+		Audience:    cfg.Auth.Audience,
+		HS256Secret: []byte(cfg.Auth.HS256Secret),
+		Leeway:      cfg.Auth.Leeway,
+	}
+
+	if cfg.Auth.RS256PublicKeyFile != "" {
+		key, err := auth.LoadRSAPublicKey(cfg.Auth.RS256PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		jwtCfg.RS256PublicKey = key
+	}
+
+	if cfg.Auth.JWKSFile != "" {
+		keys, err := auth.LoadJWKS(cfg.Auth.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		jwtCfg.JWKS = keys
+	}
+
+	return auth.NewVerifier(jwtCfg)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/metrics"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/openapi"
+	"Go-IssueTracker-API/internal/tenant"
+	"Go-IssueTracker-API/internal/tracing"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// routes holds the handlers and middlewares the router is built from.
+type routes struct {
+	issues        *handler.Handler
+	fields        *handler.FieldHandler
+	attachments   *handler.AttachmentHandler
+	watchers      *handler.WatcherHandler
+	webhooks      *handler.WebhookHandler
+	notifications *handler.NotificationHandler
+	tokens        *handler.TokenHandler
+	members       *handler.MemberHandler
+	graphql       *handler.GraphQLHandler
+	stream        *handler.StreamHandler
+	websocket     *handler.WebSocketHandler
+	health        *handler.HealthHandler
+
+	metrics *metrics.Metrics // nil when metrics are disabled
+	docs    bool             // serve the Swagger UI at /docs
+	logger  *slog.Logger
+
+	authenticate func(http.Handler) http.Handler
+	rateLimit    func(group string) func(http.Handler) http.Handler
+	idempotent   func(http.Handler) http.Handler
+}
+
+// newRouter registers all routes of the API. Every route must be described in
+// internal/openapi/openapi.yaml, which router_test.go checks.
+func newRouter(rt routes) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Use(logging.Middleware(rt.logger))
+	if rt.metrics != nil {
+		r.Use(rt.metrics.Middleware)
+		r.Method(http.MethodGet, "/metrics", rt.metrics.Handler())
+	}
+
+	// probes, build info and the API description are public, like /metrics
+	r.Get("/healthz", rt.health.Healthz)
+	r.Get("/readyz", rt.health.Readyz)
+	r.Get("/version", rt.health.Version)
+	r.Get("/openapi.json", openapi.Handler)
+	if rt.docs {
+		r.Get("/docs", openapi.Docs)
+		r.Get("/docs/{file}", openapi.Assets)
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(rt.authenticate)
+		r.Use(tenant.Middleware())
+		r.Use(logIdentity)
+		// after authentication, so callers are limited by user rather than by the credentials they send
+		r.Use(rt.rateLimit("default"))
+
+		// API tokens are limited to their scopes, users signed in with a JWT are not
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(model.ScopeIssuesRead))
+			r.Use(rt.rateLimit("read"))
+
+			r.Get("/issues/{id}", rt.issues.GetIssueByID)
+			r.Get("/issues", rt.issues.ListIssues)
+			r.Get("/issues/stream", rt.stream.Stream)
+			r.Get("/ws", rt.websocket.Connect)
+			r.Get("/issues/{id}/attachments", rt.attachments.ListAttachments)
+			r.Get("/issues/{id}/attachments/{attachmentID}", rt.attachments.GetAttachment)
+			r.Get("/users/me/watching", rt.watchers.ListWatchedIssues)
+			r.Get("/issues/{id}/subscriptions", rt.notifications.ListSubscriptions)
+			r.Get("/fields", rt.fields.ListFields)
+			r.Get("/fields/{id}", rt.fields.GetFieldByID)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(model.ScopeIssuesWrite))
+			r.Use(rt.rateLimit("write"))
+
+			r.With(rt.idempotent).Post("/issues", rt.issues.CreateIssue)
+			r.Put("/issues/{id}", rt.issues.UpdateIssue)
+			r.Delete("/issues/{id}", rt.issues.DeleteIssue)
+			r.Post("/issues/{id}/attachments", rt.attachments.UploadAttachment)
+			r.Put("/issues/{id}/watchers/me", rt.watchers.WatchIssue)
+			r.Delete("/issues/{id}/watchers/me", rt.watchers.UnwatchIssue)
+			r.With(rt.idempotent).Post("/issues/{id}/subscriptions", rt.notifications.Subscribe)
+			r.Delete("/issues/{id}/subscriptions/{subscriptionID}", rt.notifications.Unsubscribe)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(model.ScopeAdmin))
+			r.Use(rt.rateLimit("admin"))
+
+			r.With(rt.idempotent).Post("/fields", rt.fields.CreateField)
+			r.Delete("/fields/{id}", rt.fields.DeleteField)
+
+			r.With(rt.idempotent).Post("/webhooks", rt.webhooks.CreateWebhook)
+			r.Get("/webhooks", rt.webhooks.ListWebhooks)
+			r.Get("/webhooks/{id}", rt.webhooks.GetWebhook)
+			r.Put("/webhooks/{id}", rt.webhooks.UpdateWebhook)
+			r.Delete("/webhooks/{id}", rt.webhooks.DeleteWebhook)
+			r.Get("/webhooks/{id}/deliveries", rt.webhooks.ListDeliveries)
+			r.Get("/webhooks/{id}/deliveries/{deliveryID}/attempts", rt.webhooks.ListDeliveryAttempts)
+
+			r.Get("/members", rt.members.ListMembers)
+			r.Put("/members/{user}", rt.members.SetMember)
+			r.Delete("/members/{user}", rt.members.RemoveMember)
+		})
+
+		// a GraphQL request may read and write, its resolvers check the scopes
+		// and apply the read limit to queries and the write limit to mutations
+		r.With(rt.idempotent).Post("/graphql", rt.graphql.GraphQL)
+
+		// tokens can only be minted with scopes the caller already has
+		r.Post("/tokens", rt.tokens.CreateToken)
+		r.Get("/tokens", rt.tokens.ListTokens)
+		r.Delete("/tokens/{id}", rt.tokens.RevokeToken)
+	})
+
+	return r
+}
+
+// logIdentity adds the caller and tenant resolved by the authentication
+// and tenant middlewares to the request's log records.
+func logIdentity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _ := auth.UserFromContext(r.Context())
+		ctx := logging.With(r.Context(), "user", user, "tenant", tenant.ID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
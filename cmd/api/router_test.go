@@ -0,0 +1,229 @@
+package main
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/metrics"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/openapi"
+	"Go-IssueTracker-API/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func passThrough(next http.Handler) http.Handler { return next }
+
+// testRouter builds the router of main with handlers that are never called.
+func testRouter() *chi.Mux {
+	return newRouter(testRoutes())
+}
+
+func testRoutes() routes {
+	return routes{
+		issues:        &handler.Handler{},
+		fields:        &handler.FieldHandler{},
+		attachments:   &handler.AttachmentHandler{},
+		watchers:      &handler.WatcherHandler{},
+		webhooks:      &handler.WebhookHandler{},
+		notifications: &handler.NotificationHandler{},
+		tokens:        &handler.TokenHandler{},
+		members:       &handler.MemberHandler{},
+		graphql:       &handler.GraphQLHandler{},
+		stream:        &handler.StreamHandler{},
+		websocket:     &handler.WebSocketHandler{},
+		health:        &handler.HealthHandler{},
+		metrics:       metrics.New(),
+		docs:          true,
+		logger:        slog.New(slog.DiscardHandler),
+		authenticate:  passThrough,
+		rateLimit:     func(string) func(http.Handler) http.Handler { return passThrough },
+		idempotent:    passThrough,
+	}
+}
+
+type specDoc struct {
+	OpenAPI string                                `json:"openapi"`
+	Paths   map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+func loadSpec(t *testing.T) specDoc {
+	t.Helper()
+
+	body, err := openapi.Spec()
+	if err != nil {
+		t.Fatalf("openapi.yaml does not parse: %v", err)
+	}
+	var doc specDoc
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestOpenAPI_DescribesEveryRoute(t *testing.T) {
+	doc := loadSpec(t)
+	if !strings.HasPrefix(doc.OpenAPI, "3.1") {
+		t.Fatalf("expected an OpenAPI 3.1 document, got %q", doc.OpenAPI)
+	}
+
+	registered := map[string]bool{}
+	err := chi.Walk(testRouter(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := strings.ToLower(method) + " " + route
+		registered[key] = true
+		if _, ok := doc.Paths[route][strings.ToLower(method)]; !ok {
+			t.Errorf("route %s %s has no entry in internal/openapi/openapi.yaml", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, item := range doc.Paths {
+		for method := range item {
+			if method == "parameters" {
+				continue
+			}
+			if !registered[method+" "+path] {
+				t.Errorf("openapi.yaml describes %s %s, but no such route is registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+func TestOpenAPI_ReferencesResolve(t *testing.T) {
+	body, _ := openapi.Spec()
+
+	var doc map[string]any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				var target any = doc
+				for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+					m, _ := target.(map[string]any)
+					target = m[part]
+				}
+				if target == nil {
+					t.Errorf("unresolved reference %s", ref)
+				}
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	r := testRouter()
+
+	for _, path := range []string{"/openapi.json", "/docs", "/docs/swagger-ui-bundle.js", "/docs/swagger-ui.css"} {
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if res.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, res.Code)
+		}
+		wantType := map[string]string{
+			"/openapi.json":              "application/json",
+			"/docs":                      "text/html",
+			"/docs/swagger-ui-bundle.js": "text/javascript",
+			"/docs/swagger-ui.css":       "text/css",
+		}[path]
+		if got := res.Header().Get("Content-Type"); !strings.HasPrefix(got, wantType) {
+			t.Fatalf("%s: expected %s, got %s", path, wantType, got)
+		}
+	}
+
+	// only the assets of the page are served
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/docs/index.html", nil))
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected other files of the distribution to be hidden, got %d", res.Code)
+	}
+
+	var methods []string
+	for method := range loadSpec(t).Paths["/issues/{id}"] {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+	if !slices.Equal(methods, []string{"delete", "get", "parameters", "put"}) {
+		t.Fatalf("unexpected operations for /issues/{id}: %v", methods)
+	}
+}
+
+// memberRoles is a member repository with fixed roles by user.
+type memberRoles map[string]string
+
+func (m memberRoles) GetMember(ctx context.Context, user string) (*model.Member, error) {
+	role, ok := m[user]
+	if !ok {
+		return nil, model.ErrNotFound
+	}
+	return &model.Member{User: user, Role: role}, nil
+}
+
+func (m memberRoles) SetMember(ctx context.Context, member *model.Member) error { return nil }
+func (m memberRoles) DeleteMember(ctx context.Context, user string) error       { return nil }
+func (m memberRoles) ListMembers(ctx context.Context) ([]*model.Member, error)  { return nil, nil }
+
+func TestAdminRoutes_ForbiddenForOtherRoles(t *testing.T) {
+	members, err := service.NewMemberService(memberRoles{"dave": model.RoleDeveloper}, model.RoleViewer, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the repositories are never reached, the roles are checked first
+	rt := testRoutes()
+	rt.fields = handler.NewFieldHandler(service.NewFieldService(nil, service.WithFieldAuthorizer(members)))
+	rt.webhooks = handler.NewWebhookHandler(service.NewWebhookService(nil, service.WithWebhookAuthorizer(members)))
+	rt.members = handler.NewMemberHandler(members)
+	// users signed in with a JWT carry no scopes, so RequireScope lets them through
+	rt.authenticate = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), r.Header.Get("X-User"))))
+		})
+	}
+	r := newRouter(rt)
+
+	requests := []struct{ method, path, body string }{
+		{http.MethodPost, "/fields", `{"name": "customer", "type": "string"}`},
+		{http.MethodDelete, "/fields/1", ""},
+		{http.MethodPost, "/webhooks", `{"url": "https://example.com/hook", "events": ["issue.created"]}`},
+		{http.MethodGet, "/webhooks", ""},
+		{http.MethodGet, "/webhooks/1/deliveries", ""},
+		{http.MethodGet, "/members", ""},
+	}
+
+	for _, user := range []string{"vera", "dave"} { // viewer by default, developer
+		for _, tt := range requests {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("X-User", user)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != http.StatusForbidden {
+				t.Errorf("%s %s as %s: expected 403, got %d %s", tt.method, tt.path, user, res.Code, res.Body)
+			}
+		}
+	}
+}
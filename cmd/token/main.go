@@ -0,0 +1,59 @@
+// Command token prints an HS256 signed JWT for local testing,
+// using the secret, issuer and audience from config.yaml.
+//
+//	go run ./cmd/token -sub alice
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "path to the config file")
+	subject := flag.String("sub", "", "user ID to put into the token")
+	tenantID := flag.String("tenant", "", "tenant to put into the token, none by default")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	flag.Parse()
+
+	if *subject == "" {
+		log.Fatal("-sub is required")
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.Auth.HS256Secret == "" {
+		log.Fatal("auth.hs256_secret is not set, use ISSUETRACKER_AUTH_HS256_SECRET or ISSUETRACKER_AUTH_HS256_SECRET_FILE")
+	}
+
+	now := time.Now()
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   *subject,
+			Issuer:    cfg.Auth.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(*ttl)),
+		},
+		Tenant: *tenantID,
+	}
+	if cfg.Auth.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{cfg.Auth.Audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.Auth.HS256Secret))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(token)
+}
@@ -0,0 +1,54 @@
+// Package auth keeps the identity of the caller in the request context.
+package auth
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"net/http"
+	"slices"
+)
+
+type contextKey struct{}
+
+type scopesKey struct{}
+
+// WithUser returns a copy of ctx carrying the ID of the calling user.
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// UserFromContext returns the ID of the calling user, if the request is authenticated.
+func UserFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(contextKey{}).(string)
+	return user, ok && user != ""
+}
+
+// WithScopes returns a copy of ctx restricted to the given scopes.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// HasScope reports whether the caller may act within scope.
+// Callers without scopes in the context, e.g. users signed in with a JWT, are not restricted.
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, ok := ctx.Value(scopesKey{}).([]string)
+	if !ok {
+		return true
+	}
+	return slices.Contains(scopes, scope) || slices.Contains(scopes, model.ScopeAdmin)
+}
+
+// RequireScope rejects requests whose credentials do not grant scope.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasScope(r.Context(), scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				http.Error(w, "token lacks scope "+scope, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
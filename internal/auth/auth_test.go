@@ -0,0 +1,86 @@
+package auth_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHasScope(t *testing.T) {
+	ctx := context.Background()
+	if !auth.HasScope(ctx, model.ScopeAdmin) {
+		t.Fatal("expected callers without scopes to be unrestricted")
+	}
+
+	ctx = auth.WithScopes(ctx, []string{model.ScopeIssuesWrite})
+	if !auth.HasScope(ctx, model.ScopeIssuesWrite) || auth.HasScope(ctx, model.ScopeIssuesRead) {
+		t.Fatal("expected only the issues:write scope")
+	}
+
+	ctx = auth.WithScopes(ctx, []string{model.ScopeAdmin})
+	if !auth.HasScope(ctx, model.ScopeIssuesRead) {
+		t.Fatal("expected admin to grant every scope")
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	h := auth.RequireScope(model.ScopeAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodPost, "/fields", nil)
+	req = req.WithContext(auth.WithScopes(req.Context(), []string{model.ScopeIssuesRead}))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	if res.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", res.Code)
+	}
+}
+
+type staticTokens map[string][]string
+
+func (s staticTokens) AuthenticateToken(ctx context.Context, token string) (*model.APIToken, error) {
+	scopes, ok := s[token]
+	if !ok {
+		return nil, model.ErrUnauthorized
+	}
+	return &model.APIToken{User: "ci-bot", Tenant: "finance", Scopes: scopes}, nil
+}
+
+func TestMiddleware_APIToken(t *testing.T) {
+	v, err := auth.NewVerifier(auth.JWTConfig{HS256Secret: []byte("test-secret")})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	tokens := staticTokens{"itk_valid": {model.ScopeIssuesWrite}}
+
+	var gotUser, gotTenant string
+	var canRead bool
+	h := auth.Middleware(v, tokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = auth.UserFromContext(r.Context())
+		gotTenant = tenant.ID(r.Context())
+		canRead = auth.HasScope(r.Context(), model.ScopeIssuesRead)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/issues", nil)
+	req.Header.Set("Authorization", "Bearer itk_valid")
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK || gotUser != "ci-bot" || gotTenant != "finance" || canRead {
+		t.Fatalf("unexpected result: status %d, user %q, tenant %q, can read %v", res.Code, gotUser, gotTenant, canRead)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/issues", nil)
+	req.Header.Set("Authorization", "Bearer itk_revoked")
+	res = httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", res.Code)
+	}
+}
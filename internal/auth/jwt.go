@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTConfig lists the keys accepted for signed tokens.
+// At least one of HS256Secret, RS256PublicKey or JWKS must be set.
+type JWTConfig struct {
+	Issuer         string // expected "iss" claim, not checked when empty
+	Audience       string // expected "aud" claim, not checked when empty
+	HS256Secret    []byte
+	RS256PublicKey *rsa.PublicKey
+	JWKS           map[string]*rsa.PublicKey // RSA keys by key ID
+	Leeway         time.Duration             // allowed clock skew
+}
+
+// Verifier validates bearer tokens and extracts the caller identity.
+type Verifier struct {
+	cfg     JWTConfig
+	methods []string
+}
+
+func NewVerifier(cfg JWTConfig) (*Verifier, error) {
+	var methods []string
+	if len(cfg.HS256Secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.RS256PublicKey != nil || len(cfg.JWKS) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no JWT verification key configured")
+	}
+
+	return &Verifier{cfg: cfg, methods: methods}, nil
+}
+
+// Claims are the claims of an accepted token. The subject is the ID of the calling user,
+// the optional tenant claim names the organization the user belongs to.
+type Claims struct {
+	jwt.RegisteredClaims
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// Verify checks the signature and the registered claims of the token.
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(v.methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.cfg.Leeway),
+	}
+	if v.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.cfg.Issuer))
+	}
+	if v.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(v.cfg.Audience))
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, v.key, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	return &claims, nil
+}
+
+func (v *Verifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.cfg.HS256Secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		if kid, ok := token.Header["kid"].(string); ok && len(v.cfg.JWKS) > 0 {
+			if key, ok := v.cfg.JWKS[kid]; ok {
+				return key, nil
+			}
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		if v.cfg.RS256PublicKey != nil {
+			return v.cfg.RS256PublicKey, nil
+		}
+		return nil, errors.New("token has no key ID")
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// TokenAuthenticator resolves API tokens.
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*model.APIToken, error)
+}
+
+// Middleware rejects requests without a valid bearer token
+// and stores the calling user and, if the credentials name one, the tenant in the request context.
+// tokens may be nil when API tokens are not in use.
+func Middleware(v *Verifier, tokens TokenAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				unauthorized(w, "missing bearer token")
+				return
+			}
+
+			ctx, err := Authenticate(r.Context(), v, tokens, token)
+			if err != nil {
+				unauthorized(w, "invalid bearer token")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Authenticate checks a bearer token and returns a copy of ctx carrying the calling user
+// and the tenant of the credentials, tenant.Default when they name none, so a caller can never
+// pick another tenant with the X-Tenant header. Tokens starting with model.APITokenPrefix
+// are checked by tokens and restrict the caller to the token scopes, all others must be JWTs accepted by v.
+// It is shared by the HTTP middleware and the gRPC interceptors.
+func Authenticate(ctx context.Context, v *Verifier, tokens TokenAuthenticator, token string) (context.Context, error) {
+	if strings.HasPrefix(token, model.APITokenPrefix) && tokens != nil {
+		apiToken, err := tokens.AuthenticateToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		ctx = WithScopes(WithUser(ctx, apiToken.User), apiToken.Scopes)
+		return tenant.With(ctx, apiToken.Tenant), nil
+	}
+
+	claims, err := v.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	tenantID := claims.Tenant
+	if tenantID == "" {
+		tenantID = tenant.Default
+	}
+	return tenant.With(WithUser(ctx, claims.Subject), tenantID), nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="issuetracker"`)
+	http.Error(w, message, http.StatusUnauthorized)
+}
+
+// LoadRSAPublicKey reads a PEM encoded RSA public key or certificate.
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+
+	var key any
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = cert.PublicKey
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an RSA public key", path)
+	}
+	return rsaKey, nil
+}
+
+// LoadJWKS reads the RSA signing keys of a JSON Web Key Set file.
+// Keys of other types or uses are skipped.
+func LoadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %q: invalid modulus: %w", path, k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %q: invalid exponent: %w", path, k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s: no RSA signing keys found", path)
+	}
+	return keys, nil
+}
@@ -0,0 +1,268 @@
+package auth_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/tenant"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var secret = []byte("test-secret")
+
+func signHS256(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		t.Fatalf("cannot sign token: %v", err)
+	}
+	return token
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.RegisteredClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("cannot sign token: %v", err)
+	}
+	return signed
+}
+
+func validClaims(subject string) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   subject,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func TestVerify_HS256(t *testing.T) {
+	v, err := auth.NewVerifier(auth.JWTConfig{HS256Secret: secret, Issuer: "sso"})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	claims := validClaims("alice")
+	claims.Issuer = "sso"
+	got, err := v.Verify(signHS256(t, claims))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Subject != "alice" {
+		t.Fatalf("expected alice, got %q", got.Subject)
+	}
+
+	expired := validClaims("alice")
+	expired.Issuer = "sso"
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	wrongIssuer := validClaims("alice")
+	wrongIssuer.Issuer = "other"
+
+	noExpiry := validClaims("alice")
+	noExpiry.Issuer = "sso"
+	noExpiry.ExpiresAt = nil
+
+	for name, token := range map[string]string{
+		"expired":      signHS256(t, expired),
+		"wrong issuer": signHS256(t, wrongIssuer),
+		"no expiry":    signHS256(t, noExpiry),
+		"garbage":      "not.a.token",
+	} {
+		if _, err := v.Verify(token); err == nil {
+			t.Fatalf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestVerify_RejectsUnconfiguredAlgorithm(t *testing.T) {
+	v, err := auth.NewVerifier(auth.JWTConfig{HS256Secret: secret})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := v.Verify(signRS256(t, key, "", validClaims("alice"))); err == nil {
+		t.Fatal("expected RS256 token to be rejected")
+	}
+}
+
+func TestVerify_RS256PublicKeyFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600)
+
+	publicKey, err := auth.LoadRSAPublicKey(path)
+	if err != nil {
+		t.Fatalf("cannot load key: %v", err)
+	}
+
+	v, err := auth.NewVerifier(auth.JWTConfig{RS256PublicKey: publicKey})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	if claims, err := v.Verify(signRS256(t, key, "", validClaims("bob"))); err != nil || claims.Subject != "bob" {
+		t.Fatalf("expected bob, got %v, %v", claims, err)
+	}
+}
+
+func TestVerify_JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+
+	jwks := map[string]any{
+		"keys": []map[string]string{{
+			"kid": "key-1",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, _ := json.Marshal(jwks)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	os.WriteFile(path, data, 0o600)
+
+	keys, err := auth.LoadJWKS(path)
+	if err != nil {
+		t.Fatalf("cannot load jwks: %v", err)
+	}
+
+	v, err := auth.NewVerifier(auth.JWTConfig{JWKS: keys})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	if claims, err := v.Verify(signRS256(t, key, "key-1", validClaims("carol"))); err != nil || claims.Subject != "carol" {
+		t.Fatalf("expected carol, got %v, %v", claims, err)
+	}
+
+	if _, err := v.Verify(signRS256(t, key, "key-2", validClaims("carol"))); err == nil {
+		t.Fatal("expected token with unknown key ID to be rejected")
+	}
+}
+
+func TestNewVerifier_NoKeys(t *testing.T) {
+	if _, err := auth.NewVerifier(auth.JWTConfig{}); err == nil {
+		t.Fatal("expected error without keys")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	v, err := auth.NewVerifier(auth.JWTConfig{HS256Secret: secret})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	var gotUser string
+	h := auth.Middleware(v, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = auth.UserFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/issues", nil)
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", res.Code)
+	}
+	if res.Header().Get("WWW-Authenticate") == "" {
+		t.Fatal("expected WWW-Authenticate header")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/issues", nil)
+	req.Header.Set("Authorization", "Bearer "+signHS256(t, validClaims("alice")))
+	res = httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK || gotUser != "alice" {
+		t.Fatalf("expected alice to pass, got status %d user %q", res.Code, gotUser)
+	}
+}
+
+func TestMiddleware_TenantClaim(t *testing.T) {
+	v, err := auth.NewVerifier(auth.JWTConfig{HS256Secret: secret})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	var gotTenant string
+	h := auth.Middleware(v, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTenant, _ = tenant.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/issues", nil)
+	req.Header.Set("Authorization", "Bearer "+signHS256(t, auth.Claims{RegisteredClaims: validClaims("alice"), Tenant: "finance"}))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK || gotTenant != "finance" {
+		t.Fatalf("expected tenant finance, got status %d tenant %q", res.Code, gotTenant)
+	}
+}
+
+func TestMiddleware_NoTenantClaim(t *testing.T) {
+	v, err := auth.NewVerifier(auth.JWTConfig{HS256Secret: secret})
+	if err != nil {
+		t.Fatalf("cannot create verifier: %v", err)
+	}
+
+	var gotTenant string
+	h := auth.Middleware(v, nil)(tenant.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTenant = tenant.ID(r.Context())
+	})))
+
+	tests := []struct {
+		header     string
+		wantStatus int
+	}{
+		{"", http.StatusOK},
+		{tenant.Default, http.StatusOK},
+		{"finance", http.StatusForbidden}, // the header cannot pick a tenant for the credentials
+	}
+	for _, tt := range tests {
+		gotTenant = ""
+		req := httptest.NewRequest(http.MethodGet, "/issues", nil)
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, auth.Claims{RegisteredClaims: validClaims("alice")}))
+		if tt.header != "" {
+			req.Header.Set(tenant.Header, tt.header)
+		}
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+
+		if res.Code != tt.wantStatus {
+			t.Fatalf("header %q: expected status %d, got %d", tt.header, tt.wantStatus, res.Code)
+		}
+		if res.Code == http.StatusOK && gotTenant != tenant.Default {
+			t.Fatalf("header %q: expected the default tenant, got %q", tt.header, gotTenant)
+		}
+	}
+}
@@ -1,15 +1,41 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"time"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
 	Server struct {
 		Port int `yaml:"port"`
+
+		ReadTimeout       time.Duration `yaml:"read_timeout"` // whole request including the body
+		ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+		WriteTimeout      time.Duration `yaml:"write_timeout"` // from the end of the request headers to the end of the response
+		IdleTimeout       time.Duration `yaml:"idle_timeout"`  // keep-alive connections
+		MaxHeaderBytes    int           `yaml:"max_header_bytes"`
+		ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"` // how long in-flight requests may take on SIGTERM
+
+		// TLS makes the server speak HTTPS when a certificate is given.
+		TLS struct {
+			CertFile       string        `yaml:"cert_file"`
+			KeyFile        string        `yaml:"key_file"`
+			ClientCAFile   string        `yaml:"client_ca_file"`  // CAs for client certificates (mutual TLS)
+			ClientAuth     string        `yaml:"client_auth"`     // none, optional or require
+			MinVersion     string        `yaml:"min_version"`     // 1.2 or 1.3
+			ReloadInterval time.Duration `yaml:"reload_interval"` // how often the files are checked for changes
+		} `yaml:"tls"`
 	} `yaml:"server"`
 
+	Log struct {
+		Level string `yaml:"level"` // debug, info, warn or error
+	} `yaml:"log"`
+
 	Storage struct {
 		Postgres struct {
 			Host     string `yaml:"host"`
@@ -17,20 +43,193 @@ type Config struct {
 			User     string `yaml:"user"`
 			Password string `yaml:"password"`
 			Port	 int    `yaml:"port"`
+			SSLMode  string `yaml:"sslmode"` // disable, require, verify-ca or verify-full
+
+			MaxOpenConns     int           `yaml:"max_open_conns"`
+			MaxIdleConns     int           `yaml:"max_idle_conns"`
+			ConnMaxLifetime  time.Duration `yaml:"conn_max_lifetime"`
+			ConnMaxIdleTime  time.Duration `yaml:"conn_max_idle_time"`
+			StatementTimeout time.Duration `yaml:"statement_timeout"` // 0 disables it
+			StartupTimeout   time.Duration `yaml:"startup_timeout"`   // how long to wait for the database at startup
+			StartupBackoff   time.Duration `yaml:"startup_backoff"`   // first delay between attempts, doubled after each
 		} `yaml:"postgres"`
 	} `yaml:"storage"`
+
+	Auth struct {
+		Enabled            bool          `yaml:"enabled"`
+		Issuer             string        `yaml:"issuer"`
+		Audience           string        `yaml:"audience"`
+		HS256Secret        string        `yaml:"hs256_secret"`
+		RS256PublicKeyFile string        `yaml:"rs256_public_key_file"` // PEM public key or certificate
+		JWKSFile           string        `yaml:"jwks_file"`
+		Leeway             time.Duration `yaml:"leeway"`
+		DefaultRole        string        `yaml:"default_role"` // role of users without a membership
+		Admins             []string      `yaml:"admins"`       // users that always have the admin role, as tenant:user or user of the default tenant
+	} `yaml:"auth"`
+
+	// RateLimit limits requests per client, Groups are keyed by route group:
+	// "default" applies to all routes, "read", "write" and "admin" to the routes requiring that scope.
+	RateLimit struct {
+		Enabled bool `yaml:"enabled"`
+
+		Groups map[string]struct {
+			Requests int           `yaml:"requests"`
+			Per      time.Duration `yaml:"per"`
+			Burst    int           `yaml:"burst"`
+		} `yaml:"groups"`
+	} `yaml:"rate_limit"`
+
+	GRPC struct {
+		Enabled bool `yaml:"enabled"`
+		Port    int  `yaml:"port"` // served with the TLS settings of server.tls
+	} `yaml:"grpc"`
+
+	Docs struct {
+		Enabled bool `yaml:"enabled"` // serve the Swagger UI at /docs, /openapi.json is always served
+	} `yaml:"docs"`
+
+	Metrics struct {
+		Enabled bool `yaml:"enabled"` // serve /metrics, unauthenticated
+	} `yaml:"metrics"`
+
+	// Tracing exports spans of handlers, services and SQL statements.
+	Tracing struct {
+		Exporter    string  `yaml:"exporter"` // "none", "stdout" or "otlp"
+		Endpoint    string  `yaml:"endpoint"` // OTLP/HTTP collector, host:port
+		Insecure    bool    `yaml:"insecure"`
+		ServiceName string  `yaml:"service_name"`
+		SampleRatio float64 `yaml:"sample_ratio"`
+	} `yaml:"tracing"`
+
+	Idempotency struct {
+		TTL time.Duration `yaml:"ttl"` // how long responses are kept for replay
+	} `yaml:"idempotency"`
+
+	Attachments struct {
+		MaxSize int64  `yaml:"max_size"` // bytes
+		Storage string `yaml:"storage"`  // "local" or "s3"
+
+		Local struct {
+			Dir string `yaml:"dir"`
+		} `yaml:"local"`
+
+		S3 struct {
+			Endpoint  string `yaml:"endpoint"`
+			Region    string `yaml:"region"`
+			Bucket    string `yaml:"bucket"`
+			AccessKey string `yaml:"access_key"`
+			SecretKey string `yaml:"secret_key"`
+			UseSSL    bool   `yaml:"use_ssl"`
+		} `yaml:"s3"`
+	} `yaml:"attachments"`
+
+	Webhooks struct {
+		PollInterval time.Duration `yaml:"poll_interval"`
+		BatchSize    int           `yaml:"batch_size"`
+		Timeout      time.Duration `yaml:"timeout"`
+		MaxAttempts  int           `yaml:"max_attempts"`
+		BaseBackoff  time.Duration `yaml:"base_backoff"`
+		MaxBackoff   time.Duration `yaml:"max_backoff"`
+	} `yaml:"webhooks"`
+
+	Notifications struct {
+		Enabled        bool          `yaml:"enabled"`
+		PollInterval   time.Duration `yaml:"poll_interval"`
+		DigestInterval time.Duration `yaml:"digest_interval"`
+		BatchSize      int           `yaml:"batch_size"`
+		MaxAttempts    int           `yaml:"max_attempts"`
+		BaseBackoff    time.Duration `yaml:"base_backoff"`
+		MaxBackoff     time.Duration `yaml:"max_backoff"`
+
+		SMTP struct {
+			Host     string        `yaml:"host"`
+			Port     int           `yaml:"port"`
+			Username string        `yaml:"username"`
+			Password string        `yaml:"password"`
+			From     string        `yaml:"from"`
+			Timeout  time.Duration `yaml:"timeout"`
+		} `yaml:"smtp"`
+	} `yaml:"notifications"`
 }
 
+// LoadConfig reads the config file at path over the defaults, applies the
+// ISSUETRACKER_* environment overrides and validates the result.
+// With an empty path only defaults and environment are used.
 func LoadConfig(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+	var cfg Config
+	cfg.Server.Port = 8080
+	cfg.Server.ReadTimeout = time.Minute
+	cfg.Server.ReadHeaderTimeout = 10 * time.Second
+	cfg.Server.WriteTimeout = 2 * time.Minute
+	cfg.Server.IdleTimeout = 2 * time.Minute
+	cfg.Server.MaxHeaderBytes = 1 << 20
+	cfg.Server.ShutdownTimeout = 30 * time.Second
+	cfg.Server.TLS.ClientAuth = "none"
+	cfg.Server.TLS.MinVersion = "1.2"
+	cfg.Server.TLS.ReloadInterval = time.Minute
+	cfg.Log.Level = "info"
+	cfg.Storage.Postgres.Port = 5432
+	cfg.Storage.Postgres.SSLMode = "disable"
+	cfg.Storage.Postgres.MaxOpenConns = 25
+	cfg.Storage.Postgres.MaxIdleConns = 10
+	cfg.Storage.Postgres.ConnMaxLifetime = 30 * time.Minute
+	cfg.Storage.Postgres.ConnMaxIdleTime = 5 * time.Minute
+	cfg.Storage.Postgres.StatementTimeout = 30 * time.Second
+	cfg.Storage.Postgres.StartupTimeout = time.Minute
+	cfg.Storage.Postgres.StartupBackoff = 500 * time.Millisecond
+	cfg.Auth.Enabled = true
+	cfg.Auth.Leeway = 30 * time.Second
+	cfg.Auth.DefaultRole = "viewer"
+	cfg.Metrics.Enabled = true
+	cfg.GRPC.Port = 9090
+	cfg.Docs.Enabled = true
+	cfg.Tracing.Exporter = "none"
+	cfg.Tracing.Endpoint = "localhost:4318"
+	cfg.Tracing.ServiceName = "issuetracker"
+	cfg.Tracing.SampleRatio = 1
+	cfg.Idempotency.TTL = 24 * time.Hour
+	cfg.Attachments.MaxSize = 10 << 20
+	cfg.Attachments.Storage = "local"
+	cfg.Attachments.Local.Dir = "data/attachments"
+	cfg.Webhooks.PollInterval = 5 * time.Second
+	cfg.Webhooks.BatchSize = 20
+	cfg.Webhooks.Timeout = 10 * time.Second
+	cfg.Webhooks.MaxAttempts = 8
+	cfg.Webhooks.BaseBackoff = 30 * time.Second
+	cfg.Webhooks.MaxBackoff = time.Hour
+	cfg.Notifications.PollInterval = 30 * time.Second
+	cfg.Notifications.DigestInterval = time.Hour
+	cfg.Notifications.BatchSize = 50
+	cfg.Notifications.MaxAttempts = 5
+	cfg.Notifications.BaseBackoff = time.Minute
+	cfg.Notifications.MaxBackoff = time.Hour
+	cfg.Notifications.SMTP.Port = 25
+	cfg.Notifications.SMTP.Timeout = 30 * time.Second
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		// unknown keys are rejected, a misspelled setting would otherwise silently keep its default
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		// the secret signs tokens, a copy in a committed file would let anyone forge them
+		if cfg.Auth.HS256Secret != "" {
+			return nil, fmt.Errorf("%s: auth.hs256_secret must not be set in the file, use ISSUETRACKER_AUTH_HS256_SECRET or ISSUETRACKER_AUTH_HS256_SECRET_FILE", path)
+		}
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	if err := applyEnv(&cfg, os.Environ()); err != nil {
 		return nil, err
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+
 	return &cfg, nil
 }
@@ -0,0 +1,211 @@
+package config_test
+
+import (
+	"Go-IssueTracker-API/internal/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig_RepositoryConfig(t *testing.T) {
+	// the secret is deliberately not in the file
+	t.Setenv("ISSUETRACKER_AUTH_HS256_SECRET", "s3cret")
+
+	cfg, err := config.LoadConfig("../../config.yaml")
+	if err != nil {
+		t.Fatalf("config.yaml must be valid: %v", err)
+	}
+	if cfg.Server.Port != 8080 || cfg.Storage.Postgres.Host != "db" {
+		t.Fatalf("unexpected values %+v", cfg.Server)
+	}
+	// credentials come from the environment as well
+	if cfg.Storage.Postgres.Password != "" || cfg.Attachments.S3.AccessKey != "" || cfg.Attachments.S3.SecretKey != "" {
+		t.Fatal("expected no credentials in config.yaml")
+	}
+}
+
+func TestLoadConfig_EmptyFile(t *testing.T) {
+	t.Setenv("ISSUETRACKER_AUTH_HS256_SECRET", "s3cret")
+	t.Setenv("ISSUETRACKER_STORAGE_POSTGRES_HOST", "db")
+	t.Setenv("ISSUETRACKER_STORAGE_POSTGRES_DATABASE", "mydb")
+	t.Setenv("ISSUETRACKER_STORAGE_POSTGRES_USER", "app")
+
+	if _, err := config.LoadConfig(writeFile(t, "")); err != nil {
+		t.Fatalf("expected an empty file to keep the defaults, got %v", err)
+	}
+}
+
+func TestLoadConfig_EnvironmentOverrides(t *testing.T) {
+	path := writeFile(t, `
+storage:
+  postgres:
+    host: db
+    database: mydb
+    user: app
+rate_limit:
+  enabled: true
+  groups:
+    read:
+      requests: 300
+      per: 1m
+auth:
+  issuer: from-file
+`)
+
+	t.Setenv("ISSUETRACKER_SERVER_PORT", "9090")
+	t.Setenv("ISSUETRACKER_SERVER_SHUTDOWN_TIMEOUT", "5s")
+	t.Setenv("ISSUETRACKER_STORAGE_POSTGRES_PASSWORD_FILE", writeFile(t, "s3cret\n"))
+	t.Setenv("ISSUETRACKER_AUTH_HS256_SECRET", "s3cret")
+	t.Setenv("ISSUETRACKER_AUTH_ADMINS", "alice, bob")
+	t.Setenv("ISSUETRACKER_METRICS_ENABLED", "false")
+	t.Setenv("ISSUETRACKER_TRACING_SAMPLE_RATIO", "0.25")
+	t.Setenv("ISSUETRACKER_RATE_LIMIT_GROUPS_READ_BURST", "50")
+	t.Setenv("ISSUETRACKER_RATE_LIMIT_GROUPS_WRITE_REQUESTS", "10")
+	t.Setenv("ISSUETRACKER_RATE_LIMIT_GROUPS_WRITE_PER", "1m")
+
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.Port != 9090 || cfg.Server.ShutdownTimeout != 5*time.Second {
+		t.Fatalf("server not overridden: %+v", cfg.Server)
+	}
+	if cfg.Storage.Postgres.Password != "s3cret" {
+		t.Fatalf("expected the password from the file without newline, got %q", cfg.Storage.Postgres.Password)
+	}
+	if cfg.Storage.Postgres.User != "app" || cfg.Auth.Issuer != "from-file" {
+		t.Fatal("values without variables must come from the file")
+	}
+	if len(cfg.Auth.Admins) != 2 || cfg.Auth.Admins[1] != "bob" {
+		t.Fatalf("unexpected admins %q", cfg.Auth.Admins)
+	}
+	if cfg.Metrics.Enabled || cfg.Tracing.SampleRatio != 0.25 {
+		t.Fatal("metrics and tracing not overridden")
+	}
+	if read := cfg.RateLimit.Groups["read"]; read.Requests != 300 || read.Burst != 50 {
+		t.Fatalf("expected the read group merged with the file, got %+v", read)
+	}
+	if write := cfg.RateLimit.Groups["write"]; write.Requests != 10 || write.Per != time.Minute {
+		t.Fatalf("expected the write group created from the environment, got %+v", write)
+	}
+}
+
+func TestLoadConfig_WithoutFile(t *testing.T) {
+	t.Setenv("ISSUETRACKER_STORAGE_POSTGRES_HOST", "db")
+	t.Setenv("ISSUETRACKER_STORAGE_POSTGRES_DATABASE", "mydb")
+	t.Setenv("ISSUETRACKER_STORAGE_POSTGRES_USER", "app")
+	t.Setenv("ISSUETRACKER_AUTH_HS256_SECRET", "secret")
+
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 8080 || cfg.Storage.Postgres.Port != 5432 || cfg.Attachments.Storage != "local" {
+		t.Fatal("expected defaults")
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	valid := `
+storage:
+  postgres:
+    host: db
+    database: mydb
+    user: app
+`
+
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr []string
+	}{
+		{
+			name:    "invalid number",
+			file:    valid,
+			env:     map[string]string{"ISSUETRACKER_SERVER_PORT": "http"},
+			wantErr: []string{`ISSUETRACKER_SERVER_PORT: invalid integer "http"`},
+		},
+		{
+			name:    "unknown key",
+			file:    valid + "server:\n  host: 0.0.0.0\n",
+			wantErr: []string{"field host not found"},
+		},
+		{
+			name:    "secret in the file",
+			file:    valid + "auth:\n  hs256_secret: secret\n",
+			wantErr: []string{"auth.hs256_secret must not be set in the file"},
+		},
+		{
+			name:    "placeholder secret",
+			file:    valid,
+			env:     map[string]string{"ISSUETRACKER_AUTH_HS256_SECRET": "change-me-in-production"},
+			wantErr: []string{`auth.hs256_secret: must not be the placeholder "change-me-in-production"`},
+		},
+		{
+			name: "value and file",
+			file: valid,
+			env: map[string]string{
+				"ISSUETRACKER_AUTH_HS256_SECRET":      "a",
+				"ISSUETRACKER_AUTH_HS256_SECRET_FILE": "/run/secrets/jwt",
+			},
+			wantErr: []string{"ISSUETRACKER_AUTH_HS256_SECRET and ISSUETRACKER_AUTH_HS256_SECRET_FILE are both set"},
+		},
+		{
+			name:    "missing secret file",
+			file:    valid,
+			env:     map[string]string{"ISSUETRACKER_AUTH_HS256_SECRET_FILE": "/does/not/exist"},
+			wantErr: []string{"ISSUETRACKER_AUTH_HS256_SECRET_FILE:"},
+		},
+		{
+			name: "all validation errors at once",
+			file: `
+server:
+  port: 70000
+attachments:
+  storage: ftp
+tracing:
+  exporter: otlp
+  endpoint: ""
+`,
+			wantErr: []string{
+				"server.port: must be between 1 and 65535, got 70000",
+				"storage.postgres.host: is required",
+				"auth: one of hs256_secret, rs256_public_key_file or jwks_file is required",
+				`attachments.storage: must be one of ["local" "s3"], got "ftp"`,
+				"tracing.endpoint: is required for the otlp exporter",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := config.LoadConfig(writeFile(t, tt.file))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Fatalf("expected %q in error:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix starts the environment variables overriding the config file.
+// The rest of a variable name is the yaml path of the field in upper case,
+// e.g. ISSUETRACKER_STORAGE_POSTGRES_PASSWORD for storage.postgres.password.
+// Appending _FILE reads the value from a file instead, for secrets mounted into the container.
+const EnvPrefix = "ISSUETRACKER"
+
+// fileSuffix marks variables naming a file that holds the value.
+const fileSuffix = "_FILE"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv overrides the fields of cfg that have an environment variable set.
+func applyEnv(cfg *Config, environ []string) error {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(k, EnvPrefix+"_") {
+			env[k] = v
+		}
+	}
+	return applyEnvStruct(reflect.ValueOf(cfg).Elem(), EnvPrefix, env)
+}
+
+func applyEnvStruct(v reflect.Value, prefix string, env map[string]string) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		if err := applyEnvValue(v.Field(i), envName(prefix, tag), env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyEnvValue(v reflect.Value, name string, env map[string]string) error {
+	switch {
+	case v.Kind() == reflect.Struct:
+		return applyEnvStruct(v, name, env)
+	case v.Kind() == reflect.Map && v.Type().Elem().Kind() == reflect.Struct:
+		return applyEnvMap(v, name, env)
+	}
+
+	value, ok, err := lookupEnv(name, env)
+	if err != nil || !ok {
+		return err
+	}
+	if err := setValue(v, value); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// applyEnvMap overrides entries of a map of structs keyed by name, like rate_limit.groups.
+// The key sits between the map's name and the field: ISSUETRACKER_RATE_LIMIT_GROUPS_WRITE_REQUESTS
+// sets requests of the "write" entry, creating the entry if the config file has none.
+func applyEnvMap(v reflect.Value, name string, env map[string]string) error {
+	elemType := v.Type().Elem()
+
+	keys := map[string]bool{}
+	for k := range env {
+		rest, ok := strings.CutPrefix(strings.TrimSuffix(k, fileSuffix), name+"_")
+		if !ok {
+			continue
+		}
+		for i := range elemType.NumField() {
+			tag, _, _ := strings.Cut(elemType.Field(i).Tag.Get("yaml"), ",")
+			if key, ok := strings.CutSuffix(rest, "_"+envName("", tag)); ok && key != "" {
+				keys[strings.ToLower(key)] = true
+			}
+		}
+	}
+
+	for key := range keys {
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+
+		// map entries are not addressable, so the entry is copied, changed and stored back
+		entry := reflect.New(elemType).Elem()
+		if existing := v.MapIndex(reflect.ValueOf(key)); existing.IsValid() {
+			entry.Set(existing)
+		}
+		if err := applyEnvStruct(entry, envName(name, key), env); err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(key), entry)
+	}
+	return nil
+}
+
+// lookupEnv returns the value of the variable name, or the content of the file named by name_FILE.
+func lookupEnv(name string, env map[string]string) (string, bool, error) {
+	value, ok := env[name]
+	path, fromFile := env[name+fileSuffix]
+	switch {
+	case ok && fromFile:
+		return "", false, fmt.Errorf("%s and %s are both set, use one of them", name, name+fileSuffix)
+	case fromFile:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%s: %w", name+fileSuffix, err)
+		}
+		// editors and "echo secret > file" leave a trailing newline
+		return strings.TrimRight(string(data), "\r\n"), true, nil
+	}
+	return value, ok, nil
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		// lists are comma separated, an empty value clears the list
+		list := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = reflect.Append(list, reflect.ValueOf(item))
+			}
+		}
+		v.Set(list)
+	default:
+		return fmt.Errorf("cannot be set from the environment")
+	}
+	return nil
+}
+
+func envName(prefix, tag string) string {
+	name := strings.ToUpper(strings.ReplaceAll(tag, ".", "_"))
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
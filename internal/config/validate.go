@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// placeholderSecret is the HS256 secret the example configuration used to ship with.
+// It is public, so tokens signed with it can be forged.
+const placeholderSecret = "change-me-in-production"
+
+// Validate reports every missing or invalid value at once, each prefixed with its yaml path.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, field, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: "+format, append([]any{field}, args...)...))
+		}
+	}
+	positive := func(d time.Duration, field string) {
+		check(d > 0, field, "must be a positive duration, got %s", d)
+	}
+	oneOf := func(value, field string, allowed ...string) {
+		check(slices.Contains(allowed, value), field, "must be one of %q, got %q", allowed, value)
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "server.port", "must be between 1 and 65535, got %d", c.Server.Port)
+	positive(c.Server.ReadTimeout, "server.read_timeout")
+	positive(c.Server.ReadHeaderTimeout, "server.read_header_timeout")
+	positive(c.Server.WriteTimeout, "server.write_timeout")
+	positive(c.Server.IdleTimeout, "server.idle_timeout")
+	positive(c.Server.ShutdownTimeout, "server.shutdown_timeout")
+	check(c.Server.MaxHeaderBytes > 0, "server.max_header_bytes", "must be positive, got %d", c.Server.MaxHeaderBytes)
+
+	if c.GRPC.Enabled {
+		check(c.GRPC.Port > 0 && c.GRPC.Port < 65536, "grpc.port", "must be between 1 and 65535, got %d", c.GRPC.Port)
+		check(c.GRPC.Port != c.Server.Port, "grpc.port", "must differ from server.port")
+	}
+
+	tls := c.Server.TLS
+	check((tls.CertFile == "") == (tls.KeyFile == ""), "server.tls", "cert_file and key_file must be given together")
+	oneOf(tls.ClientAuth, "server.tls.client_auth", "none", "optional", "require")
+	if tls.ClientAuth != "none" {
+		check(tls.CertFile != "", "server.tls.client_auth", "needs cert_file and key_file")
+		check(tls.ClientCAFile != "", "server.tls.client_ca_file", "is required for client_auth %q", tls.ClientAuth)
+	}
+	oneOf(tls.MinVersion, "server.tls.min_version", "1.2", "1.3")
+	positive(tls.ReloadInterval, "server.tls.reload_interval")
+
+	oneOf(c.Log.Level, "log.level", "debug", "info", "warn", "error")
+
+	pg := c.Storage.Postgres
+	check(pg.Host != "", "storage.postgres.host", "is required")
+	check(pg.Port > 0 && pg.Port < 65536, "storage.postgres.port", "must be between 1 and 65535, got %d", pg.Port)
+	check(pg.Database != "", "storage.postgres.database", "is required")
+	check(pg.User != "", "storage.postgres.user", "is required")
+	oneOf(pg.SSLMode, "storage.postgres.sslmode", "disable", "require", "verify-ca", "verify-full")
+	check(pg.MaxOpenConns >= 0, "storage.postgres.max_open_conns", "must not be negative, got %d", pg.MaxOpenConns)
+	check(pg.MaxIdleConns >= 0, "storage.postgres.max_idle_conns", "must not be negative, got %d", pg.MaxIdleConns)
+	if pg.MaxOpenConns > 0 {
+		check(pg.MaxIdleConns <= pg.MaxOpenConns, "storage.postgres.max_idle_conns", "must not exceed max_open_conns (%d), got %d", pg.MaxOpenConns, pg.MaxIdleConns)
+	}
+	check(pg.ConnMaxLifetime >= 0, "storage.postgres.conn_max_lifetime", "must not be negative, got %s", pg.ConnMaxLifetime)
+	check(pg.ConnMaxIdleTime >= 0, "storage.postgres.conn_max_idle_time", "must not be negative, got %s", pg.ConnMaxIdleTime)
+	check(pg.StatementTimeout >= 0, "storage.postgres.statement_timeout", "must not be negative, got %s", pg.StatementTimeout)
+	positive(pg.StartupTimeout, "storage.postgres.startup_timeout")
+	positive(pg.StartupBackoff, "storage.postgres.startup_backoff")
+
+	if c.Auth.Enabled {
+		check(c.Auth.HS256Secret != "" || c.Auth.RS256PublicKeyFile != "" || c.Auth.JWKSFile != "",
+			"auth", "one of hs256_secret, rs256_public_key_file or jwks_file is required when authentication is enabled")
+		check(c.Auth.HS256Secret != placeholderSecret, "auth.hs256_secret", "must not be the placeholder %q", placeholderSecret)
+	}
+	check(c.Auth.Leeway >= 0, "auth.leeway", "must not be negative, got %s", c.Auth.Leeway)
+	check(c.Auth.DefaultRole != "", "auth.default_role", "is required")
+
+	if c.RateLimit.Enabled {
+		for _, name := range slices.Sorted(maps.Keys(c.RateLimit.Groups)) {
+			group := c.RateLimit.Groups[name]
+			field := "rate_limit.groups." + name
+			check(group.Requests > 0, field+".requests", "must be positive, got %d", group.Requests)
+			positive(group.Per, field+".per")
+			check(group.Burst >= 0, field+".burst", "must not be negative, got %d", group.Burst)
+		}
+	}
+
+	oneOf(c.Tracing.Exporter, "tracing.exporter", "none", "stdout", "otlp")
+	if c.Tracing.Exporter == "otlp" {
+		check(c.Tracing.Endpoint != "", "tracing.endpoint", "is required for the otlp exporter")
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	positive(c.Idempotency.TTL, "idempotency.ttl")
+
+	check(c.Attachments.MaxSize > 0, "attachments.max_size", "must be positive, got %d", c.Attachments.MaxSize)
+	oneOf(c.Attachments.Storage, "attachments.storage", "local", "s3")
+	switch c.Attachments.Storage {
+	case "local":
+		check(c.Attachments.Local.Dir != "", "attachments.local.dir", "is required for local storage")
+	case "s3":
+		check(c.Attachments.S3.Endpoint != "", "attachments.s3.endpoint", "is required for s3 storage")
+		check(c.Attachments.S3.Bucket != "", "attachments.s3.bucket", "is required for s3 storage")
+	}
+
+	positive(c.Webhooks.PollInterval, "webhooks.poll_interval")
+	positive(c.Webhooks.Timeout, "webhooks.timeout")
+	positive(c.Webhooks.BaseBackoff, "webhooks.base_backoff")
+	check(c.Webhooks.MaxBackoff >= c.Webhooks.BaseBackoff, "webhooks.max_backoff", "must not be less than base_backoff (%s), got %s", c.Webhooks.BaseBackoff, c.Webhooks.MaxBackoff)
+	check(c.Webhooks.BatchSize > 0, "webhooks.batch_size", "must be positive, got %d", c.Webhooks.BatchSize)
+	check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts", "must be positive, got %d", c.Webhooks.MaxAttempts)
+
+	if c.Notifications.Enabled {
+		n := c.Notifications
+		positive(n.PollInterval, "notifications.poll_interval")
+		positive(n.DigestInterval, "notifications.digest_interval")
+		check(n.BatchSize > 0, "notifications.batch_size", "must be positive, got %d", n.BatchSize)
+		check(n.MaxAttempts > 0, "notifications.max_attempts", "must be positive, got %d", n.MaxAttempts)
+		positive(n.BaseBackoff, "notifications.base_backoff")
+		check(n.MaxBackoff >= n.BaseBackoff, "notifications.max_backoff", "must not be less than base_backoff (%s), got %s", n.BaseBackoff, n.MaxBackoff)
+		check(n.SMTP.Host != "", "notifications.smtp.host", "is required when notifications are enabled")
+		check(n.SMTP.Port > 0 && n.SMTP.Port < 65536, "notifications.smtp.port", "must be between 1 and 65535, got %d", n.SMTP.Port)
+		check(n.SMTP.From != "", "notifications.smtp.from", "is required when notifications are enabled")
+		positive(n.SMTP.Timeout, "notifications.smtp.timeout")
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+// Package database opens the PostgreSQL connection pool.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// pingTimeout bounds a single connection attempt at startup.
+const pingTimeout = 5 * time.Second
+
+// maxBackoff caps the delay between connection attempts at startup.
+const maxBackoff = 15 * time.Second
+
+type Config struct {
+	Host     string
+	Port     int
+	Database string
+	User     string
+	Password string
+	SSLMode  string // disable, require, verify-ca or verify-full
+
+	MaxOpenConns    int // 0 means unlimited
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration // 0 keeps connections forever
+	ConnMaxIdleTime time.Duration
+	// StatementTimeout makes the server cancel statements running longer, 0 disables it.
+	StatementTimeout time.Duration
+
+	// StartupTimeout is how long Open keeps retrying while the database is not reachable,
+	// StartupBackoff the delay before the first retry, doubled after every failed attempt.
+	StartupTimeout time.Duration
+	StartupBackoff time.Duration
+}
+
+// DSN returns the connection URL for lib/pq, with user and password escaped.
+func (c Config) DSN() string {
+	query := url.Values{}
+	query.Set("sslmode", c.SSLMode)
+	query.Set("connect_timeout", strconv.Itoa(int(pingTimeout/time.Second)))
+	if c.StatementTimeout > 0 {
+		// unknown parameters are sent to the server as run-time settings of the session
+		query.Set("statement_timeout", strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10))
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:     "/" + c.Database,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// Open creates the connection pool and waits until the database answers,
+// so the server does not crash-loop when it starts before PostgreSQL.
+func Open(ctx context.Context, cfg Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN())
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := WaitReady(ctx, db, cfg.StartupTimeout, cfg.StartupBackoff); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// WaitReady pings db until it answers, with exponential backoff between attempts.
+// It gives up with the last error once timeout has passed or ctx is done.
+func WaitReady(ctx context.Context, db Pinger, timeout, backoff time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancelPing := context.WithTimeout(ctx, pingTimeout)
+		err := db.PingContext(pingCtx)
+		cancelPing()
+		if err == nil {
+			return nil
+		}
+
+		slog.WarnContext(ctx, "Database not reachable, retrying", "attempt", attempt, "retry_in", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database not reachable after %d attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
@@ -0,0 +1,74 @@
+package database_test
+
+import (
+	"Go-IssueTracker-API/internal/database"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type flakyDB struct {
+	failures int
+	calls    int
+}
+
+func (db *flakyDB) PingContext(ctx context.Context) error {
+	db.calls++
+	if db.calls <= db.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestDSN(t *testing.T) {
+	dsn := database.Config{
+		Host:             "db",
+		Port:             5432,
+		Database:         "mydb",
+		User:             "task-service",
+		Password:         "p@ss/word?",
+		SSLMode:          "verify-full",
+		StatementTimeout: 30 * time.Second,
+	}.DSN()
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("invalid DSN %q: %v", dsn, err)
+	}
+	if password, _ := u.User.Password(); password != "p@ss/word?" {
+		t.Fatalf("password not escaped: %q", dsn)
+	}
+	if u.Host != "db:5432" || u.Path != "/mydb" {
+		t.Fatalf("unexpected DSN %q", dsn)
+	}
+	query := u.Query()
+	if query.Get("sslmode") != "verify-full" || query.Get("statement_timeout") != "30000" {
+		t.Fatalf("unexpected parameters %q", u.RawQuery)
+	}
+}
+
+func TestWaitReady_Retries(t *testing.T) {
+	db := &flakyDB{failures: 2}
+
+	if err := database.WaitReady(context.Background(), db, time.Second, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if db.calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", db.calls)
+	}
+}
+
+func TestWaitReady_GivesUp(t *testing.T) {
+	db := &flakyDB{failures: 1 << 30}
+
+	err := database.WaitReady(context.Background(), db, 50*time.Millisecond, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected an error once the timeout passed")
+	}
+	// 10ms, 20ms, then the deadline
+	if db.calls < 2 || db.calls > 4 {
+		t.Fatalf("expected backoff between attempts, got %d attempts", db.calls)
+	}
+}
@@ -0,0 +1,194 @@
+// Package eventbus fans issue events out to subscribers in the same process,
+// e.g. clients streaming changes over gRPC or Server-Sent Events.
+package eventbus
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBuffer is the number of events a subscriber may fall behind before it is dropped.
+	DefaultBuffer = 64
+	// DefaultHistory is the number of recent events kept to resume subscriptions.
+	DefaultHistory = 1024
+)
+
+// Event is an issue event numbered by the bus.
+type Event struct {
+	// ID is unique and increasing within the bus, and differs between processes,
+	// so an ID from before a restart is not mistaken for a recent one.
+	ID string
+	model.IssueEvent
+}
+
+type record struct {
+	seq    uint64
+	tenant string
+	event  Event
+}
+
+// Bus implements service.EventPublisher. Publishing never blocks: a subscriber
+// whose buffer is full is dropped, so a slow client cannot delay changes to issues.
+type Bus struct {
+	buffer int
+	epoch  string
+
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	closed  bool
+	seq     uint64
+	history []record // ring of the last events, history[seq%len(history)]
+}
+
+// New returns a bus giving every subscriber a buffer of the given size and keeping
+// the given number of events for Resume. Sizes that are not positive select the defaults.
+func New(buffer, history int) *Bus {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	if history <= 0 {
+		history = DefaultHistory
+	}
+	return &Bus{
+		buffer:  buffer,
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		subs:    make(map[*Subscription]struct{}),
+		history: make([]record, history),
+	}
+}
+
+// Subscription receives the events of one tenant until it is closed.
+type Subscription struct {
+	bus    *Bus
+	tenant string
+	ch     chan Event
+
+	dropped bool // guarded by bus.mu
+	closed  bool
+}
+
+// Subscribe returns a subscription to the events of the tenant of ctx published from now on.
+// It must be closed when the subscriber is done.
+func (b *Bus) Subscribe(ctx context.Context) *Subscription {
+	s, _ := b.Resume(ctx, "")
+	return s
+}
+
+// Resume is like Subscribe, but first delivers the kept events of the tenant published after
+// the event with lastID. It reports whether no events were missed: false if lastID is
+// unknown or older than the kept events. An empty lastID resumes from now on.
+func (b *Bus) Resume(ctx context.Context, lastID string) (*Subscription, bool) {
+	t := tenant.ID(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+	complete := true
+	if lastID != "" {
+		after, ok := b.parseID(lastID)
+		complete = ok && after <= b.seq && b.seq-after <= uint64(len(b.history))
+		if complete {
+			for seq := after + 1; seq <= b.seq; seq++ {
+				if r := b.history[seq%uint64(len(b.history))]; r.tenant == t {
+					replay = append(replay, r.event)
+				}
+			}
+		}
+	}
+
+	s := &Subscription{bus: b, tenant: t, ch: make(chan Event, b.buffer+len(replay))}
+	for _, event := range replay {
+		s.ch <- event
+	}
+	if b.closed {
+		s.closed = true
+		close(s.ch)
+		return s, complete
+	}
+	b.subs[s] = struct{}{}
+	return s, complete
+}
+
+// parseID returns the sequence number of an ID of this bus.
+func (b *Bus) parseID(id string) (uint64, bool) {
+	epoch, seq, ok := strings.Cut(id, "-")
+	if !ok || epoch != b.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	return n, err == nil
+}
+
+// Close ends all subscriptions, so streaming clients are let go on shutdown.
+// Later subscriptions are closed right away.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for s := range b.subs {
+		b.remove(s)
+	}
+}
+
+// Publish numbers event and delivers it to the subscribers of the tenant of ctx.
+func (b *Bus) Publish(ctx context.Context, event model.IssueEvent) error {
+	t := tenant.ID(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e := Event{ID: fmt.Sprintf("%s-%d", b.epoch, b.seq), IssueEvent: event}
+	b.history[b.seq%uint64(len(b.history))] = record{seq: b.seq, tenant: t, event: e}
+
+	for s := range b.subs {
+		if s.tenant != t {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped = true
+			b.remove(s)
+		}
+	}
+	return nil
+}
+
+// remove closes the channel of s, b.mu must be held.
+func (b *Bus) remove(s *Subscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(b.subs, s)
+	close(s.ch)
+}
+
+// Events returns the channel events are delivered on.
+// It is closed when the subscription or the bus is closed, or the subscription is dropped.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Dropped reports whether the bus closed the subscription because the subscriber fell behind.
+func (s *Subscription) Dropped() bool {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.dropped
+}
+
+// Close stops the delivery of events. It may be called more than once.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s)
+}
@@ -0,0 +1,135 @@
+package eventbus_test
+
+import (
+	"Go-IssueTracker-API/internal/eventbus"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"testing"
+)
+
+func TestBus_DeliversToSubscribersOfTheTenant(t *testing.T) {
+	bus := eventbus.New(8, 0)
+	acme := tenant.With(context.Background(), "acme")
+	other := tenant.With(context.Background(), "other")
+
+	sub := bus.Subscribe(acme)
+	defer sub.Close()
+
+	bus.Publish(other, model.IssueEvent{Type: model.EventIssueCreated, IssueID: 1})
+	bus.Publish(acme, model.IssueEvent{Type: model.EventIssueCreated, IssueID: 2})
+
+	event := <-sub.Events()
+	if event.IssueID != 2 {
+		t.Fatalf("expected only the event of the tenant, got issue %d", event.IssueID)
+	}
+	select {
+	case event := <-sub.Events():
+		t.Fatalf("unexpected event %+v", event)
+	default:
+	}
+}
+
+func TestBus_DropsSlowSubscriber(t *testing.T) {
+	bus := eventbus.New(2, 0)
+	ctx := context.Background()
+
+	slow := bus.Subscribe(ctx)
+	fast := bus.Subscribe(ctx)
+	defer fast.Close()
+
+	for id := range 3 {
+		bus.Publish(ctx, model.IssueEvent{IssueID: id})
+		<-fast.Events()
+	}
+
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+	if received != 2 || !slow.Dropped() {
+		t.Fatalf("expected the buffered events and a dropped subscription, got %d, %v", received, slow.Dropped())
+	}
+	if fast.Dropped() {
+		t.Fatal("the subscriber keeping up must not be dropped")
+	}
+	slow.Close() // closing a dropped subscription is fine
+}
+
+func TestBus_Close(t *testing.T) {
+	bus := eventbus.New(2, 0)
+	ctx := context.Background()
+	sub := bus.Subscribe(ctx)
+
+	bus.Close()
+
+	if _, ok := <-sub.Events(); ok || sub.Dropped() {
+		t.Fatal("expected the subscription to end without being dropped")
+	}
+	if _, ok := <-bus.Subscribe(ctx).Events(); ok {
+		t.Fatal("expected subscriptions after Close to be closed")
+	}
+	bus.Publish(ctx, model.IssueEvent{IssueID: 1})
+}
+
+func TestBus_Resume(t *testing.T) {
+	bus := eventbus.New(8, 4)
+	acme := tenant.With(context.Background(), "acme")
+	other := tenant.With(context.Background(), "other")
+
+	sub := bus.Subscribe(acme)
+	defer sub.Close()
+	bus.Publish(acme, model.IssueEvent{IssueID: 1})
+	first := <-sub.Events()
+	bus.Publish(other, model.IssueEvent{IssueID: 2})
+	bus.Publish(acme, model.IssueEvent{IssueID: 3})
+
+	resumed, complete := bus.Resume(acme, first.ID)
+	defer resumed.Close()
+	if !complete {
+		t.Fatal("expected a complete resume")
+	}
+	if event := <-resumed.Events(); event.IssueID != 3 {
+		t.Fatalf("expected the missed event of the tenant, got issue %d", event.IssueID)
+	}
+
+	bus.Publish(acme, model.IssueEvent{IssueID: 4})
+	if event := <-resumed.Events(); event.IssueID != 4 {
+		t.Fatalf("expected live events after the replay, got issue %d", event.IssueID)
+	}
+}
+
+func TestBus_ResumeIncomplete(t *testing.T) {
+	bus := eventbus.New(8, 2)
+	ctx := context.Background()
+
+	sub := bus.Subscribe(ctx)
+	defer sub.Close()
+	for id := range 4 {
+		bus.Publish(ctx, model.IssueEvent{IssueID: id})
+	}
+	oldest := <-sub.Events()
+
+	tests := []struct {
+		name   string
+		lastID string
+	}{
+		{"too old", oldest.ID},
+		{"other process", "0-1"},
+		{"malformed", "nope"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resumed, complete := bus.Resume(ctx, tt.lastID)
+			defer resumed.Close()
+			if complete {
+				t.Fatal("expected an incomplete resume")
+			}
+			select {
+			case event := <-resumed.Events():
+				t.Fatalf("unexpected replay of %+v", event)
+			default:
+			}
+		})
+	}
+}
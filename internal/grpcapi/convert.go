@@ -0,0 +1,83 @@
+package grpcapi
+
+import (
+	pb "Go-IssueTracker-API/internal/grpcapi/issuetrackerv1"
+	"Go-IssueTracker-API/internal/model"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var statuses = map[string]pb.Status{
+	"open":        pb.Status_STATUS_OPEN,
+	"in_progress": pb.Status_STATUS_IN_PROGRESS,
+	"done":        pb.Status_STATUS_DONE,
+}
+
+var eventTypes = map[string]pb.EventType{
+	model.EventIssueCreated:       pb.EventType_EVENT_TYPE_ISSUE_CREATED,
+	model.EventIssueUpdated:       pb.EventType_EVENT_TYPE_ISSUE_UPDATED,
+	model.EventIssueDeleted:       pb.EventType_EVENT_TYPE_ISSUE_DELETED,
+	model.EventIssueStatusChanged: pb.EventType_EVENT_TYPE_ISSUE_STATUS_CHANGED,
+}
+
+// fromStatus returns the status name of s. STATUS_UNSPECIFIED maps to the empty
+// name, which the service rejects like any other invalid status.
+func fromStatus(s pb.Status) string {
+	for name, status := range statuses {
+		if status == s {
+			return name
+		}
+	}
+	return ""
+}
+
+func toEventType(eventType string) pb.EventType {
+	return eventTypes[eventType]
+}
+
+func toIssue(issue *model.Issue) *pb.Issue {
+	if issue == nil {
+		return nil
+	}
+	return &pb.Issue{
+		Id:           int64(issue.ID),
+		Title:        issue.Title,
+		Description:  issue.Description,
+		Status:       statuses[issue.Status],
+		Reporter:     issue.Reporter,
+		Assignee:     issue.Assignee,
+		UpdatedBy:    issue.UpdatedBy,
+		CustomFields: toStruct(issue.CustomFields),
+	}
+}
+
+func toEvent(event model.IssueEvent) *pb.WatchIssuesResponse {
+	return &pb.WatchIssuesResponse{
+		Type:       toEventType(event.Type),
+		IssueId:    int64(event.IssueID),
+		Issue:      toIssue(event.Issue),
+		Previous:   toIssue(event.Previous),
+		Actor:      event.Actor,
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+}
+
+// toStruct converts custom field values, which come from JSON and so always fit a Struct.
+func toStruct(fields map[string]any) *structpb.Struct {
+	if fields == nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+func fromStruct(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
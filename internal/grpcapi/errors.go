@@ -0,0 +1,44 @@
+package grpcapi
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps service errors to gRPC status codes, like writeError does to HTTP status codes.
+// Field errors are attached as a google.rpc.BadRequest detail.
+func toStatus(err error) error {
+	var validation *model.ValidationError
+	switch {
+	case errors.As(err, &validation):
+		details := &errdetails.BadRequest{}
+		for _, fe := range validation.Errors {
+			details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fe.Field,
+				Reason:      fe.Code,
+				Description: fe.Message,
+			})
+		}
+		st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(details)
+		if detailErr != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return st.Err()
+	case errors.Is(err, model.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, model.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, model.ErrUnauthorized):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, model.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, model.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,235 @@
+package grpcapi
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	pb "Go-IssueTracker-API/internal/grpcapi/issuetrackerv1"
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/ratelimit"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// TenantMetadata names the tenant of anonymous calls, like the X-Tenant header.
+// Authenticated calls act for the tenant of their credentials.
+const TenantMetadata = "x-tenant"
+
+// scopes lists the scope each method of IssueService requires, like the route groups of the REST API.
+// Methods of other services, health checks and reflection, are served without authentication.
+var scopes = map[string]string{
+	pb.IssueService_CreateIssue_FullMethodName: model.ScopeIssuesWrite,
+	pb.IssueService_GetIssue_FullMethodName:    model.ScopeIssuesRead,
+	pb.IssueService_UpdateIssue_FullMethodName: model.ScopeIssuesWrite,
+	pb.IssueService_DeleteIssue_FullMethodName: model.ScopeIssuesWrite,
+	pb.IssueService_ListIssues_FullMethodName:  model.ScopeIssuesRead,
+	pb.IssueService_WatchIssues_FullMethodName: model.ScopeIssuesRead,
+}
+
+// rateLimitGroups lists the rate limit group each method of IssueService counts against,
+// in addition to the default group, like the route groups of the REST API.
+var rateLimitGroups = map[string]string{
+	pb.IssueService_CreateIssue_FullMethodName: "write",
+	pb.IssueService_GetIssue_FullMethodName:    "read",
+	pb.IssueService_UpdateIssue_FullMethodName: "write",
+	pb.IssueService_DeleteIssue_FullMethodName: "write",
+	pb.IssueService_ListIssues_FullMethodName:  "read",
+	pb.IssueService_WatchIssues_FullMethodName: "read",
+}
+
+type Config struct {
+	// Authenticate checks the bearer token of a call, see auth.Authenticate.
+	// Calls are anonymous when it is nil.
+	Authenticate func(ctx context.Context, token string) (context.Context, error)
+	TLS          *tls.Config // nil serves plain text
+	Logger       *slog.Logger
+	// RateLimits are the limiters of the rate limit groups shared with the REST API.
+	// Groups without a limiter are not limited.
+	RateLimits map[string]*ratelimit.Limiter
+}
+
+// NewServer returns a gRPC server with IssueService, the standard health service and reflection registered.
+func NewServer(issues IssueService, events EventSource, cfg Config) *grpc.Server {
+	i := &interceptors{cfg: cfg}
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// the rate limits run after the caller is identified, so they can tell callers apart
+		grpc.ChainUnaryInterceptor(i.unary, i.rateLimitUnary),
+		grpc.ChainStreamInterceptor(i.stream, i.rateLimitStream),
+	}
+	if cfg.TLS != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg.TLS)))
+	}
+
+	srv := grpc.NewServer(opts...)
+	pb.RegisterIssueServiceServer(srv, &issueServer{issues: issues, events: events})
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	reflection.Register(srv)
+	return srv
+}
+
+type interceptors struct {
+	cfg Config
+}
+
+func (i *interceptors) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	ctx, done := i.begin(ctx, info.FullMethod)
+	defer func() { done(err) }()
+
+	if ctx, err = i.identify(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *interceptors) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	ctx, done := i.begin(ss.Context(), info.FullMethod)
+	defer func() { done(err) }()
+
+	if ctx, err = i.identify(ctx, info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+func (i *interceptors) rateLimitUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := i.limit(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *interceptors) rateLimitStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := i.limit(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// limit takes a request from the default group and the group of the method, keyed by the caller
+// or its address. Calls over a limit fail with ResourceExhausted and a RetryInfo detail.
+func (i *interceptors) limit(ctx context.Context, method string) error {
+	group, ok := rateLimitGroups[method]
+	if !ok {
+		return nil
+	}
+
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+	key := ratelimit.Key(ctx, addr)
+
+	for _, name := range []string{"default", group} {
+		limiter, ok := i.cfg.RateLimits[name]
+		if !ok {
+			continue
+		}
+		if res := limiter.Allow(key); !res.Allowed {
+			st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+			if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(res.RetryAfter)}); err == nil {
+				st = detailed
+			}
+			return st.Err()
+		}
+	}
+	return nil
+}
+
+// begin puts a logger into ctx and returns a function writing the access log record of the call.
+func (i *interceptors) begin(ctx context.Context, method string) (context.Context, func(error)) {
+	start := time.Now()
+
+	logger := i.cfg.Logger.With("rpc_method", method)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		logger = logger.With("trace_id", sc.TraceID().String())
+	}
+	ctx = logging.WithLogger(ctx, logger)
+
+	return ctx, func(err error) {
+		code := status.Code(err)
+		level := slog.LevelInfo
+		if code == codes.Internal || code == codes.Unknown {
+			level = slog.LevelError
+		}
+		user, _ := auth.UserFromContext(ctx)
+		logger.Log(ctx, level, "rpc",
+			"code", code.String(),
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			"user", user,
+		)
+	}
+}
+
+// identify authenticates the call and resolves its tenant, like the HTTP middlewares do for requests.
+func (i *interceptors) identify(ctx context.Context, method string) (context.Context, error) {
+	scope, ok := scopes[method]
+	if !ok {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if i.cfg.Authenticate != nil {
+		token, ok := bearerToken(md)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		var err error
+		if ctx, err = i.cfg.Authenticate(ctx, token); err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+	}
+
+	var requested string
+	if values := md.Get(TenantMetadata); len(values) > 0 {
+		requested = values[0]
+	}
+	ctx, err := tenant.Resolve(ctx, requested)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if !auth.HasScope(ctx, scope) {
+		return nil, status.Error(codes.PermissionDenied, "token lacks scope "+scope)
+	}
+	return logging.WithLogger(ctx, logging.FromContext(ctx).With("tenant", tenant.ID(ctx))), nil
+}
+
+func bearerToken(md metadata.MD) (string, bool) {
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// serverStream replaces the context of a stream with the one carrying the caller.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,278 @@
+package grpcapi_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/eventbus"
+	"Go-IssueTracker-API/internal/grpcapi"
+	pb "Go-IssueTracker-API/internal/grpcapi/issuetrackerv1"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/ratelimit"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type MockIssueService struct {
+	CreateFunc  func(ctx context.Context, issue *model.Issue) (int, error)
+	GetByIDFunc func(ctx context.Context, id int) (*model.Issue, error)
+	UpdateFunc  func(ctx context.Context, issue *model.Issue) error
+	DeleteFunc  func(ctx context.Context, id int) error
+	ListFunc    func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error)
+}
+
+func (m *MockIssueService) CreateIssue(ctx context.Context, issue *model.Issue) (int, error) {
+	return m.CreateFunc(ctx, issue)
+}
+
+func (m *MockIssueService) GetIssueByID(ctx context.Context, id int) (*model.Issue, error) {
+	return m.GetByIDFunc(ctx, id)
+}
+
+func (m *MockIssueService) UpdateIssue(ctx context.Context, issue *model.Issue) error {
+	return m.UpdateFunc(ctx, issue)
+}
+
+func (m *MockIssueService) DeleteIssue(ctx context.Context, id int) error {
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *MockIssueService) ListIssues(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
+	return m.ListFunc(ctx, filter)
+}
+
+// tokens accepted by the test server: "read" may only read, "alice" may do everything in tenant acme
+func authenticate(ctx context.Context, token string) (context.Context, error) {
+	switch token {
+	case "read":
+		return auth.WithScopes(auth.WithUser(ctx, "bob"), []string{model.ScopeIssuesRead}), nil
+	case "alice":
+		return tenant.With(auth.WithUser(ctx, "alice"), "acme"), nil
+	}
+	return nil, errors.New("unknown token")
+}
+
+// startServer serves the API in memory and returns a client for it.
+func startServer(t *testing.T, issues grpcapi.IssueService, bus *eventbus.Bus) pb.IssueServiceClient {
+	t.Helper()
+	return startServerWith(t, issues, bus, grpcapi.Config{})
+}
+
+// startServerWith is startServer with more settings, the authentication and logger are set for the tests.
+func startServerWith(t *testing.T, issues grpcapi.IssueService, bus *eventbus.Bus, cfg grpcapi.Config) pb.IssueServiceClient {
+	t.Helper()
+
+	cfg.Authenticate = authenticate
+	cfg.Logger = slog.New(slog.DiscardHandler)
+	ln := bufconn.Listen(1 << 20)
+	srv := grpcapi.NewServer(issues, bus, cfg)
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewIssueServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPC_CreateAndGetIssue(t *testing.T) {
+	var created *model.Issue
+	client := startServer(t, &MockIssueService{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			if user, _ := auth.UserFromContext(ctx); user != "alice" || tenant.ID(ctx) != "acme" {
+				t.Errorf("expected alice in acme, got %q in %q", user, tenant.ID(ctx))
+			}
+			issue.ID = 7
+			issue.Status = "open"
+			created = issue
+			return 7, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			if id != 7 {
+				return nil, model.ErrNotFound
+			}
+			return created, nil
+		},
+	}, eventbus.New(0, 0))
+
+	ctx := withToken("alice")
+	res, err := client.CreateIssue(ctx, &pb.CreateIssueRequest{Title: "Login fails", Assignee: "carol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetIssue().GetId() != 7 || res.GetIssue().GetStatus() != pb.Status_STATUS_OPEN {
+		t.Fatalf("unexpected issue %v", res.GetIssue())
+	}
+
+	got, err := client.GetIssue(ctx, &pb.GetIssueRequest{Id: 7})
+	if err != nil || got.GetIssue().GetAssignee() != "carol" {
+		t.Fatalf("unexpected result %v, %v", got, err)
+	}
+
+	_, err = client.GetIssue(ctx, &pb.GetIssueRequest{Id: 8})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestGRPC_ValidationErrorDetails(t *testing.T) {
+	client := startServer(t, &MockIssueService{
+		UpdateFunc: func(ctx context.Context, issue *model.Issue) error {
+			if issue.Status != "" {
+				t.Errorf("expected STATUS_UNSPECIFIED to map to an empty status, got %q", issue.Status)
+			}
+			var v model.ValidationError
+			v.Add("status", model.CodeInvalidValue, "must be one of open, in_progress, done")
+			return &v
+		},
+	}, eventbus.New(0, 0))
+
+	_, err := client.UpdateIssue(withToken("alice"), &pb.UpdateIssueRequest{Id: 1, Title: "Test"})
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("expected a BadRequest detail, got %v", st.Details())
+	}
+	details, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || details.GetFieldViolations()[0].GetField() != "status" {
+		t.Fatalf("unexpected details %v", st.Details())
+	}
+}
+
+func TestGRPC_Authentication(t *testing.T) {
+	client := startServer(t, &MockIssueService{
+		DeleteFunc: func(ctx context.Context, id int) error {
+			t.Error("expected DeleteIssue not to be called")
+			return nil
+		},
+	}, eventbus.New(0, 0))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"missing token", context.Background(), codes.Unauthenticated},
+		{"invalid token", withToken("nope"), codes.Unauthenticated},
+		{"missing scope", withToken("read"), codes.PermissionDenied},
+		{"other tenant", metadata.AppendToOutgoingContext(withToken("alice"), grpcapi.TenantMetadata, "other"), codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.DeleteIssue(tt.ctx, &pb.DeleteIssueRequest{Id: 1})
+			if status.Code(err) != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGRPC_RateLimits(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 1, Per: time.Hour}
+	client := startServerWith(t, &MockIssueService{
+		DeleteFunc: func(ctx context.Context, id int) error { return nil },
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			return &model.Issue{ID: id, Title: "Login fails", Status: "open"}, nil
+		},
+	}, eventbus.New(0, 0), grpcapi.Config{
+		RateLimits: map[string]*ratelimit.Limiter{
+			"read":  ratelimit.New(limit, nil),
+			"write": ratelimit.New(limit, nil),
+		},
+	})
+
+	ctx := withToken("alice")
+	if _, err := client.DeleteIssue(ctx, &pb.DeleteIssueRequest{Id: 1}); err != nil {
+		t.Fatalf("expected the first write to pass, got %v", err)
+	}
+	_, err := client.DeleteIssue(ctx, &pb.DeleteIssueRequest{Id: 1})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	var retry *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = info
+		}
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() <= 0 {
+		t.Fatalf("expected a retry delay, got %v", status.Convert(err).Details())
+	}
+
+	// reads have their own limit, and so do other callers
+	if _, err := client.GetIssue(ctx, &pb.GetIssueRequest{Id: 1}); err != nil {
+		t.Fatalf("expected the read to pass, got %v", err)
+	}
+	if _, err := client.GetIssue(withToken("read"), &pb.GetIssueRequest{Id: 1}); err != nil {
+		t.Fatalf("expected another caller to pass, got %v", err)
+	}
+}
+
+func TestGRPC_WatchIssues(t *testing.T) {
+	bus := eventbus.New(0, 0)
+	client := startServer(t, &MockIssueService{}, bus)
+
+	ctx, cancel := context.WithTimeout(withToken("alice"), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchIssues(ctx, &pb.WatchIssuesRequest{
+		IssueIds: []int64{1},
+		Types:    []pb.EventType{pb.EventType_EVENT_TYPE_ISSUE_STATUS_CHANGED},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the server sends headers once it has subscribed
+	if _, err := stream.Header(); err != nil {
+		t.Fatal(err)
+	}
+
+	acme := tenant.With(context.Background(), "acme")
+	bus.Publish(tenant.With(context.Background(), "other"), model.IssueEvent{Type: model.EventIssueStatusChanged, IssueID: 1})
+	bus.Publish(acme, model.IssueEvent{Type: model.EventIssueUpdated, IssueID: 1})
+	bus.Publish(acme, model.IssueEvent{Type: model.EventIssueStatusChanged, IssueID: 2})
+	bus.Publish(acme, model.IssueEvent{
+		Type:     model.EventIssueStatusChanged,
+		IssueID:  1,
+		Issue:    &model.Issue{ID: 1, Status: "done"},
+		Previous: &model.Issue{ID: 1, Status: "open"},
+		Actor:    "alice",
+	})
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.GetIssueId() != 1 || event.GetIssue().GetStatus() != pb.Status_STATUS_DONE || event.GetPrevious().GetStatus() != pb.Status_STATUS_OPEN {
+		t.Fatalf("unexpected event %v", event)
+	}
+
+	bus.Close()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable on shutdown, got %v", err)
+	}
+}
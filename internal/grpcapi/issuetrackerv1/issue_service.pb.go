@@ -0,0 +1,1029 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: issuetracker/v1/issue_service.proto
+
+package issuetrackerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_OPEN        Status = 1
+	Status_STATUS_IN_PROGRESS Status = 2
+	Status_STATUS_DONE        Status = 3
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_OPEN",
+		2: "STATUS_IN_PROGRESS",
+		3: "STATUS_DONE",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_OPEN":        1,
+		"STATUS_IN_PROGRESS": 2,
+		"STATUS_DONE":        3,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_issuetracker_v1_issue_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_issuetracker_v1_issue_service_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{0}
+}
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED          EventType = 0
+	EventType_EVENT_TYPE_ISSUE_CREATED        EventType = 1
+	EventType_EVENT_TYPE_ISSUE_UPDATED        EventType = 2
+	EventType_EVENT_TYPE_ISSUE_DELETED        EventType = 3
+	EventType_EVENT_TYPE_ISSUE_STATUS_CHANGED EventType = 4
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_ISSUE_CREATED",
+		2: "EVENT_TYPE_ISSUE_UPDATED",
+		3: "EVENT_TYPE_ISSUE_DELETED",
+		4: "EVENT_TYPE_ISSUE_STATUS_CHANGED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":          0,
+		"EVENT_TYPE_ISSUE_CREATED":        1,
+		"EVENT_TYPE_ISSUE_UPDATED":        2,
+		"EVENT_TYPE_ISSUE_DELETED":        3,
+		"EVENT_TYPE_ISSUE_STATUS_CHANGED": 4,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_issuetracker_v1_issue_service_proto_enumTypes[1].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_issuetracker_v1_issue_service_proto_enumTypes[1]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{1}
+}
+
+type Issue struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      Status                 `protobuf:"varint,4,opt,name=status,proto3,enum=issuetracker.v1.Status" json:"status,omitempty"`
+	Reporter    string                 `protobuf:"bytes,5,opt,name=reporter,proto3" json:"reporter,omitempty"`
+	Assignee    string                 `protobuf:"bytes,6,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	UpdatedBy   string                 `protobuf:"bytes,7,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// Values of custom fields by field name.
+	CustomFields  *structpb.Struct `protobuf:"bytes,8,opt,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Issue) Reset() {
+	*x = Issue{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Issue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Issue) ProtoMessage() {}
+
+func (x *Issue) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Issue.ProtoReflect.Descriptor instead.
+func (*Issue) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Issue) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Issue) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Issue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Issue) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *Issue) GetReporter() string {
+	if x != nil {
+		return x.Reporter
+	}
+	return ""
+}
+
+func (x *Issue) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *Issue) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Issue) GetCustomFields() *structpb.Struct {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type CreateIssueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Assignee      string                 `protobuf:"bytes,3,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	CustomFields  *structpb.Struct       `protobuf:"bytes,4,opt,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIssueRequest) Reset() {
+	*x = CreateIssueRequest{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIssueRequest) ProtoMessage() {}
+
+func (x *CreateIssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIssueRequest.ProtoReflect.Descriptor instead.
+func (*CreateIssueRequest) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateIssueRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetCustomFields() *structpb.Struct {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type CreateIssueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issue         *Issue                 `protobuf:"bytes,1,opt,name=issue,proto3" json:"issue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIssueResponse) Reset() {
+	*x = CreateIssueResponse{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIssueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIssueResponse) ProtoMessage() {}
+
+func (x *CreateIssueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIssueResponse.ProtoReflect.Descriptor instead.
+func (*CreateIssueResponse) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateIssueResponse) GetIssue() *Issue {
+	if x != nil {
+		return x.Issue
+	}
+	return nil
+}
+
+type GetIssueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIssueRequest) Reset() {
+	*x = GetIssueRequest{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIssueRequest) ProtoMessage() {}
+
+func (x *GetIssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIssueRequest.ProtoReflect.Descriptor instead.
+func (*GetIssueRequest) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetIssueRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetIssueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issue         *Issue                 `protobuf:"bytes,1,opt,name=issue,proto3" json:"issue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIssueResponse) Reset() {
+	*x = GetIssueResponse{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIssueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIssueResponse) ProtoMessage() {}
+
+func (x *GetIssueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIssueResponse.ProtoReflect.Descriptor instead.
+func (*GetIssueResponse) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetIssueResponse) GetIssue() *Issue {
+	if x != nil {
+		return x.Issue
+	}
+	return nil
+}
+
+type UpdateIssueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status        Status                 `protobuf:"varint,4,opt,name=status,proto3,enum=issuetracker.v1.Status" json:"status,omitempty"`
+	Assignee      string                 `protobuf:"bytes,5,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	CustomFields  *structpb.Struct       `protobuf:"bytes,6,opt,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIssueRequest) Reset() {
+	*x = UpdateIssueRequest{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIssueRequest) ProtoMessage() {}
+
+func (x *UpdateIssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIssueRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIssueRequest) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateIssueRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateIssueRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateIssueRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetCustomFields() *structpb.Struct {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type UpdateIssueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIssueResponse) Reset() {
+	*x = UpdateIssueResponse{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIssueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIssueResponse) ProtoMessage() {}
+
+func (x *UpdateIssueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIssueResponse.ProtoReflect.Descriptor instead.
+func (*UpdateIssueResponse) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{6}
+}
+
+type DeleteIssueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIssueRequest) Reset() {
+	*x = DeleteIssueRequest{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIssueRequest) ProtoMessage() {}
+
+func (x *DeleteIssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIssueRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIssueRequest) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteIssueRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteIssueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIssueResponse) Reset() {
+	*x = DeleteIssueResponse{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIssueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIssueResponse) ProtoMessage() {}
+
+func (x *DeleteIssueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIssueResponse.ProtoReflect.Descriptor instead.
+func (*DeleteIssueResponse) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{8}
+}
+
+type ListIssuesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Custom field values issues must have, like the field.<name> query parameters.
+	CustomFields  map[string]string `protobuf:"bytes,1,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIssuesRequest) Reset() {
+	*x = ListIssuesRequest{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIssuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIssuesRequest) ProtoMessage() {}
+
+func (x *ListIssuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIssuesRequest.ProtoReflect.Descriptor instead.
+func (*ListIssuesRequest) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListIssuesRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type ListIssuesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issues        []*Issue               `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIssuesResponse) Reset() {
+	*x = ListIssuesResponse{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIssuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIssuesResponse) ProtoMessage() {}
+
+func (x *ListIssuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIssuesResponse.ProtoReflect.Descriptor instead.
+func (*ListIssuesResponse) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListIssuesResponse) GetIssues() []*Issue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+type WatchIssuesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream changes of these issues, all issues when empty.
+	IssueIds []int64 `protobuf:"varint,1,rep,packed,name=issue_ids,json=issueIds,proto3" json:"issue_ids,omitempty"`
+	// Only stream these kinds of changes, all when empty.
+	Types         []EventType `protobuf:"varint,2,rep,packed,name=types,proto3,enum=issuetracker.v1.EventType" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchIssuesRequest) Reset() {
+	*x = WatchIssuesRequest{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchIssuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchIssuesRequest) ProtoMessage() {}
+
+func (x *WatchIssuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchIssuesRequest.ProtoReflect.Descriptor instead.
+func (*WatchIssuesRequest) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchIssuesRequest) GetIssueIds() []int64 {
+	if x != nil {
+		return x.IssueIds
+	}
+	return nil
+}
+
+func (x *WatchIssuesRequest) GetTypes() []EventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type WatchIssuesResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=issuetracker.v1.EventType" json:"type,omitempty"`
+	IssueId int64                  `protobuf:"varint,2,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	// The issue after the change, unset for deletions.
+	Issue *Issue `protobuf:"bytes,3,opt,name=issue,proto3" json:"issue,omitempty"`
+	// The issue before an update or deletion.
+	Previous      *Issue                 `protobuf:"bytes,4,opt,name=previous,proto3" json:"previous,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchIssuesResponse) Reset() {
+	*x = WatchIssuesResponse{}
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchIssuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchIssuesResponse) ProtoMessage() {}
+
+func (x *WatchIssuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issuetracker_v1_issue_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchIssuesResponse.ProtoReflect.Descriptor instead.
+func (*WatchIssuesResponse) Descriptor() ([]byte, []int) {
+	return file_issuetracker_v1_issue_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchIssuesResponse) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WatchIssuesResponse) GetIssueId() int64 {
+	if x != nil {
+		return x.IssueId
+	}
+	return 0
+}
+
+func (x *WatchIssuesResponse) GetIssue() *Issue {
+	if x != nil {
+		return x.Issue
+	}
+	return nil
+}
+
+func (x *WatchIssuesResponse) GetPrevious() *Issue {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *WatchIssuesResponse) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *WatchIssuesResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_issuetracker_v1_issue_service_proto protoreflect.FileDescriptor
+
+const file_issuetracker_v1_issue_service_proto_rawDesc = "" +
+	"\n" +
+	"#issuetracker/v1/issue_service.proto\x12\x0fissuetracker.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x02\n" +
+	"\x05Issue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12/\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x17.issuetracker.v1.StatusR\x06status\x12\x1a\n" +
+	"\breporter\x18\x05 \x01(\tR\breporter\x12\x1a\n" +
+	"\bassignee\x18\x06 \x01(\tR\bassignee\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\a \x01(\tR\tupdatedBy\x12<\n" +
+	"\rcustom_fields\x18\b \x01(\v2\x17.google.protobuf.StructR\fcustomFields\"\xa6\x01\n" +
+	"\x12CreateIssueRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bassignee\x18\x03 \x01(\tR\bassignee\x12<\n" +
+	"\rcustom_fields\x18\x04 \x01(\v2\x17.google.protobuf.StructR\fcustomFields\"C\n" +
+	"\x13CreateIssueResponse\x12,\n" +
+	"\x05issue\x18\x01 \x01(\v2\x16.issuetracker.v1.IssueR\x05issue\"!\n" +
+	"\x0fGetIssueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"@\n" +
+	"\x10GetIssueResponse\x12,\n" +
+	"\x05issue\x18\x01 \x01(\v2\x16.issuetracker.v1.IssueR\x05issue\"\xe7\x01\n" +
+	"\x12UpdateIssueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12/\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x17.issuetracker.v1.StatusR\x06status\x12\x1a\n" +
+	"\bassignee\x18\x05 \x01(\tR\bassignee\x12<\n" +
+	"\rcustom_fields\x18\x06 \x01(\v2\x17.google.protobuf.StructR\fcustomFields\"\x15\n" +
+	"\x13UpdateIssueResponse\"$\n" +
+	"\x12DeleteIssueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x15\n" +
+	"\x13DeleteIssueResponse\"\xaf\x01\n" +
+	"\x11ListIssuesRequest\x12Y\n" +
+	"\rcustom_fields\x18\x01 \x03(\v24.issuetracker.v1.ListIssuesRequest.CustomFieldsEntryR\fcustomFields\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"D\n" +
+	"\x12ListIssuesResponse\x12.\n" +
+	"\x06issues\x18\x01 \x03(\v2\x16.issuetracker.v1.IssueR\x06issues\"c\n" +
+	"\x12WatchIssuesRequest\x12\x1b\n" +
+	"\tissue_ids\x18\x01 \x03(\x03R\bissueIds\x120\n" +
+	"\x05types\x18\x02 \x03(\x0e2\x1a.issuetracker.v1.EventTypeR\x05types\"\x95\x02\n" +
+	"\x13WatchIssuesResponse\x12.\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1a.issuetracker.v1.EventTypeR\x04type\x12\x19\n" +
+	"\bissue_id\x18\x02 \x01(\x03R\aissueId\x12,\n" +
+	"\x05issue\x18\x03 \x01(\v2\x16.issuetracker.v1.IssueR\x05issue\x122\n" +
+	"\bprevious\x18\x04 \x01(\v2\x16.issuetracker.v1.IssueR\bprevious\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*Z\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vSTATUS_OPEN\x10\x01\x12\x16\n" +
+	"\x12STATUS_IN_PROGRESS\x10\x02\x12\x0f\n" +
+	"\vSTATUS_DONE\x10\x03*\xa6\x01\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18EVENT_TYPE_ISSUE_CREATED\x10\x01\x12\x1c\n" +
+	"\x18EVENT_TYPE_ISSUE_UPDATED\x10\x02\x12\x1c\n" +
+	"\x18EVENT_TYPE_ISSUE_DELETED\x10\x03\x12#\n" +
+	"\x1fEVENT_TYPE_ISSUE_STATUS_CHANGED\x10\x042\xa0\x04\n" +
+	"\fIssueService\x12X\n" +
+	"\vCreateIssue\x12#.issuetracker.v1.CreateIssueRequest\x1a$.issuetracker.v1.CreateIssueResponse\x12O\n" +
+	"\bGetIssue\x12 .issuetracker.v1.GetIssueRequest\x1a!.issuetracker.v1.GetIssueResponse\x12X\n" +
+	"\vUpdateIssue\x12#.issuetracker.v1.UpdateIssueRequest\x1a$.issuetracker.v1.UpdateIssueResponse\x12X\n" +
+	"\vDeleteIssue\x12#.issuetracker.v1.DeleteIssueRequest\x1a$.issuetracker.v1.DeleteIssueResponse\x12U\n" +
+	"\n" +
+	"ListIssues\x12\".issuetracker.v1.ListIssuesRequest\x1a#.issuetracker.v1.ListIssuesResponse\x12Z\n" +
+	"\vWatchIssues\x12#.issuetracker.v1.WatchIssuesRequest\x1a$.issuetracker.v1.WatchIssuesResponse0\x01BDZBGo-IssueTracker-API/internal/grpcapi/issuetrackerv1;issuetrackerv1b\x06proto3"
+
+var (
+	file_issuetracker_v1_issue_service_proto_rawDescOnce sync.Once
+	file_issuetracker_v1_issue_service_proto_rawDescData []byte
+)
+
+func file_issuetracker_v1_issue_service_proto_rawDescGZIP() []byte {
+	file_issuetracker_v1_issue_service_proto_rawDescOnce.Do(func() {
+		file_issuetracker_v1_issue_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_issuetracker_v1_issue_service_proto_rawDesc), len(file_issuetracker_v1_issue_service_proto_rawDesc)))
+	})
+	return file_issuetracker_v1_issue_service_proto_rawDescData
+}
+
+var file_issuetracker_v1_issue_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_issuetracker_v1_issue_service_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_issuetracker_v1_issue_service_proto_goTypes = []any{
+	(Status)(0),                   // 0: issuetracker.v1.Status
+	(EventType)(0),                // 1: issuetracker.v1.EventType
+	(*Issue)(nil),                 // 2: issuetracker.v1.Issue
+	(*CreateIssueRequest)(nil),    // 3: issuetracker.v1.CreateIssueRequest
+	(*CreateIssueResponse)(nil),   // 4: issuetracker.v1.CreateIssueResponse
+	(*GetIssueRequest)(nil),       // 5: issuetracker.v1.GetIssueRequest
+	(*GetIssueResponse)(nil),      // 6: issuetracker.v1.GetIssueResponse
+	(*UpdateIssueRequest)(nil),    // 7: issuetracker.v1.UpdateIssueRequest
+	(*UpdateIssueResponse)(nil),   // 8: issuetracker.v1.UpdateIssueResponse
+	(*DeleteIssueRequest)(nil),    // 9: issuetracker.v1.DeleteIssueRequest
+	(*DeleteIssueResponse)(nil),   // 10: issuetracker.v1.DeleteIssueResponse
+	(*ListIssuesRequest)(nil),     // 11: issuetracker.v1.ListIssuesRequest
+	(*ListIssuesResponse)(nil),    // 12: issuetracker.v1.ListIssuesResponse
+	(*WatchIssuesRequest)(nil),    // 13: issuetracker.v1.WatchIssuesRequest
+	(*WatchIssuesResponse)(nil),   // 14: issuetracker.v1.WatchIssuesResponse
+	nil,                           // 15: issuetracker.v1.ListIssuesRequest.CustomFieldsEntry
+	(*structpb.Struct)(nil),       // 16: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+}
+var file_issuetracker_v1_issue_service_proto_depIdxs = []int32{
+	0,  // 0: issuetracker.v1.Issue.status:type_name -> issuetracker.v1.Status
+	16, // 1: issuetracker.v1.Issue.custom_fields:type_name -> google.protobuf.Struct
+	16, // 2: issuetracker.v1.CreateIssueRequest.custom_fields:type_name -> google.protobuf.Struct
+	2,  // 3: issuetracker.v1.CreateIssueResponse.issue:type_name -> issuetracker.v1.Issue
+	2,  // 4: issuetracker.v1.GetIssueResponse.issue:type_name -> issuetracker.v1.Issue
+	0,  // 5: issuetracker.v1.UpdateIssueRequest.status:type_name -> issuetracker.v1.Status
+	16, // 6: issuetracker.v1.UpdateIssueRequest.custom_fields:type_name -> google.protobuf.Struct
+	15, // 7: issuetracker.v1.ListIssuesRequest.custom_fields:type_name -> issuetracker.v1.ListIssuesRequest.CustomFieldsEntry
+	2,  // 8: issuetracker.v1.ListIssuesResponse.issues:type_name -> issuetracker.v1.Issue
+	1,  // 9: issuetracker.v1.WatchIssuesRequest.types:type_name -> issuetracker.v1.EventType
+	1,  // 10: issuetracker.v1.WatchIssuesResponse.type:type_name -> issuetracker.v1.EventType
+	2,  // 11: issuetracker.v1.WatchIssuesResponse.issue:type_name -> issuetracker.v1.Issue
+	2,  // 12: issuetracker.v1.WatchIssuesResponse.previous:type_name -> issuetracker.v1.Issue
+	17, // 13: issuetracker.v1.WatchIssuesResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	3,  // 14: issuetracker.v1.IssueService.CreateIssue:input_type -> issuetracker.v1.CreateIssueRequest
+	5,  // 15: issuetracker.v1.IssueService.GetIssue:input_type -> issuetracker.v1.GetIssueRequest
+	7,  // 16: issuetracker.v1.IssueService.UpdateIssue:input_type -> issuetracker.v1.UpdateIssueRequest
+	9,  // 17: issuetracker.v1.IssueService.DeleteIssue:input_type -> issuetracker.v1.DeleteIssueRequest
+	11, // 18: issuetracker.v1.IssueService.ListIssues:input_type -> issuetracker.v1.ListIssuesRequest
+	13, // 19: issuetracker.v1.IssueService.WatchIssues:input_type -> issuetracker.v1.WatchIssuesRequest
+	4,  // 20: issuetracker.v1.IssueService.CreateIssue:output_type -> issuetracker.v1.CreateIssueResponse
+	6,  // 21: issuetracker.v1.IssueService.GetIssue:output_type -> issuetracker.v1.GetIssueResponse
+	8,  // 22: issuetracker.v1.IssueService.UpdateIssue:output_type -> issuetracker.v1.UpdateIssueResponse
+	10, // 23: issuetracker.v1.IssueService.DeleteIssue:output_type -> issuetracker.v1.DeleteIssueResponse
+	12, // 24: issuetracker.v1.IssueService.ListIssues:output_type -> issuetracker.v1.ListIssuesResponse
+	14, // 25: issuetracker.v1.IssueService.WatchIssues:output_type -> issuetracker.v1.WatchIssuesResponse
+	20, // [20:26] is the sub-list for method output_type
+	14, // [14:20] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_issuetracker_v1_issue_service_proto_init() }
+func file_issuetracker_v1_issue_service_proto_init() {
+	if File_issuetracker_v1_issue_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_issuetracker_v1_issue_service_proto_rawDesc), len(file_issuetracker_v1_issue_service_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_issuetracker_v1_issue_service_proto_goTypes,
+		DependencyIndexes: file_issuetracker_v1_issue_service_proto_depIdxs,
+		EnumInfos:         file_issuetracker_v1_issue_service_proto_enumTypes,
+		MessageInfos:      file_issuetracker_v1_issue_service_proto_msgTypes,
+	}.Build()
+	File_issuetracker_v1_issue_service_proto = out.File
+	file_issuetracker_v1_issue_service_proto_goTypes = nil
+	file_issuetracker_v1_issue_service_proto_depIdxs = nil
+}
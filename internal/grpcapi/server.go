@@ -0,0 +1,142 @@
+// Package grpcapi serves issuetracker.v1.IssueService, the gRPC counterpart of the issue routes.
+package grpcapi
+
+import (
+	"Go-IssueTracker-API/internal/eventbus"
+	pb "Go-IssueTracker-API/internal/grpcapi/issuetrackerv1"
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IssueService is implemented by service.IssueService.
+type IssueService interface {
+	CreateIssue(ctx context.Context, issue *model.Issue) (int, error)
+	GetIssueByID(ctx context.Context, id int) (*model.Issue, error)
+	UpdateIssue(ctx context.Context, issue *model.Issue) error
+	DeleteIssue(ctx context.Context, id int) error
+	ListIssues(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error)
+}
+
+// EventSource is implemented by eventbus.Bus.
+type EventSource interface {
+	Subscribe(ctx context.Context) *eventbus.Subscription
+}
+
+type issueServer struct {
+	pb.UnimplementedIssueServiceServer
+
+	issues IssueService
+	events EventSource
+}
+
+func (s *issueServer) CreateIssue(ctx context.Context, req *pb.CreateIssueRequest) (*pb.CreateIssueResponse, error) {
+	issue := &model.Issue{
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		Assignee:     req.GetAssignee(),
+		CustomFields: fromStruct(req.GetCustomFields()),
+	}
+
+	if _, err := s.issues.CreateIssue(ctx, issue); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreateIssueResponse{Issue: toIssue(issue)}, nil
+}
+
+func (s *issueServer) GetIssue(ctx context.Context, req *pb.GetIssueRequest) (*pb.GetIssueResponse, error) {
+	issue, err := s.issues.GetIssueByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetIssueResponse{Issue: toIssue(issue)}, nil
+}
+
+func (s *issueServer) UpdateIssue(ctx context.Context, req *pb.UpdateIssueRequest) (*pb.UpdateIssueResponse, error) {
+	issue := &model.Issue{
+		ID:           int(req.GetId()),
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		Status:       fromStatus(req.GetStatus()),
+		Assignee:     req.GetAssignee(),
+		CustomFields: fromStruct(req.GetCustomFields()),
+	}
+
+	if err := s.issues.UpdateIssue(ctx, issue); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateIssueResponse{}, nil
+}
+
+func (s *issueServer) DeleteIssue(ctx context.Context, req *pb.DeleteIssueRequest) (*pb.DeleteIssueResponse, error) {
+	if err := s.issues.DeleteIssue(ctx, int(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteIssueResponse{}, nil
+}
+
+func (s *issueServer) ListIssues(ctx context.Context, req *pb.ListIssuesRequest) (*pb.ListIssuesResponse, error) {
+	var filter model.IssueFilter
+	for name, value := range req.GetCustomFields() {
+		if filter.CustomFields == nil {
+			filter.CustomFields = make(map[string]any)
+		}
+		filter.CustomFields[name] = value
+	}
+
+	issues, err := s.issues.ListIssues(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &pb.ListIssuesResponse{Issues: make([]*pb.Issue, len(issues))}
+	for i, issue := range issues {
+		res.Issues[i] = toIssue(issue)
+	}
+	return res, nil
+}
+
+func (s *issueServer) WatchIssues(req *pb.WatchIssuesRequest, stream grpc.ServerStreamingServer[pb.WatchIssuesResponse]) error {
+	ctx := stream.Context()
+	sub := s.events.Subscribe(ctx)
+	defer sub.Close()
+
+	// headers tell the client the subscription is in place before the first event arrives
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok && sub.Dropped() {
+				return status.Error(codes.ResourceExhausted, "client fell behind the event stream, reconnect to resume")
+			}
+			if !ok {
+				return status.Error(codes.Unavailable, "server is shutting down, reconnect to resume")
+			}
+			if !watched(req, event.IssueEvent) {
+				continue
+			}
+			if err := stream.Send(toEvent(event.IssueEvent)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func watched(req *pb.WatchIssuesRequest, event model.IssueEvent) bool {
+	if ids := req.GetIssueIds(); len(ids) > 0 && !slices.Contains(ids, int64(event.IssueID)) {
+		return false
+	}
+	if types := req.GetTypes(); len(types) > 0 && !slices.Contains(types, toEventType(event.Type)) {
+		return false
+	}
+	return true
+}
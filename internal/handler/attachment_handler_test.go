@@ -0,0 +1,141 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MockAttachmentService struct {
+	UploadFunc func(ctx context.Context, attachment *model.Attachment, content io.Reader) (int, error)
+	GetFunc    func(ctx context.Context, issueID, id int) (*model.Attachment, io.ReadCloser, error)
+	ListFunc   func(ctx context.Context, issueID int) ([]*model.Attachment, error)
+	BatchFunc  func(ctx context.Context, issueIDs []int) ([]*model.Attachment, error)
+}
+
+func (m *MockAttachmentService) UploadAttachment(ctx context.Context, attachment *model.Attachment, content io.Reader) (int, error) {
+	return m.UploadFunc(ctx, attachment, content)
+}
+
+func (m *MockAttachmentService) GetAttachment(ctx context.Context, issueID, id int) (*model.Attachment, io.ReadCloser, error) {
+	return m.GetFunc(ctx, issueID, id)
+}
+
+func (m *MockAttachmentService) ListAttachments(ctx context.Context, issueID int) ([]*model.Attachment, error) {
+	return m.ListFunc(ctx, issueID)
+}
+
+func (m *MockAttachmentService) ListAttachmentsForIssues(ctx context.Context, issueIDs []int) ([]*model.Attachment, error) {
+	return m.BatchFunc(ctx, issueIDs)
+}
+
+func multipartBody(t *testing.T, filename, content string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("cannot create form file: %v", err)
+	}
+	part.Write([]byte(content))
+	mw.Close()
+
+	return &body, mw.FormDataContentType()
+}
+
+func TestUploadAttachment(t *testing.T) {
+	var got model.Attachment
+	var gotContent string
+	mockService := &MockAttachmentService{
+		UploadFunc: func(ctx context.Context, attachment *model.Attachment, content io.Reader) (int, error) {
+			got = *attachment
+			data, _ := io.ReadAll(content)
+			gotContent = string(data)
+			return 3, nil
+		},
+	}
+
+	h := handler.NewAttachmentHandler(mockService, 1<<20)
+	r := chi.NewRouter()
+	r.Post("/issues/{id}/attachments", h.UploadAttachment)
+
+	body, contentType := multipartBody(t, "screenshot.png", "png bytes")
+	req := httptest.NewRequest(http.MethodPost, "/issues/2/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", res.Code, res.Body)
+	}
+
+	if got.IssueID != 2 || got.Filename != "screenshot.png" {
+		t.Fatalf("unexpected attachment %+v", got)
+	}
+
+	if gotContent != "png bytes" {
+		t.Fatalf("expected file content to be streamed, got %q", gotContent)
+	}
+}
+
+func TestUploadAttachment_TooLarge(t *testing.T) {
+	mockService := &MockAttachmentService{
+		UploadFunc: func(ctx context.Context, attachment *model.Attachment, content io.Reader) (int, error) {
+			_, err := io.ReadAll(content)
+			return 0, err
+		},
+	}
+
+	h := handler.NewAttachmentHandler(mockService, 64)
+	r := chi.NewRouter()
+	r.Post("/issues/{id}/attachments", h.UploadAttachment)
+
+	body, contentType := multipartBody(t, "big.log", strings.Repeat("x", 1024))
+	req := httptest.NewRequest(http.MethodPost, "/issues/2/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d", res.Code)
+	}
+}
+
+func TestGetAttachment(t *testing.T) {
+	mockService := &MockAttachmentService{
+		GetFunc: func(ctx context.Context, issueID, id int) (*model.Attachment, io.ReadCloser, error) {
+			attachment := &model.Attachment{ID: id, IssueID: issueID, Filename: "app.log", ContentType: "text/plain", Size: 5}
+			return attachment, io.NopCloser(strings.NewReader("hello")), nil
+		},
+	}
+
+	h := handler.NewAttachmentHandler(mockService, 1<<20)
+	r := chi.NewRouter()
+	r.Get("/issues/{id}/attachments/{attachmentID}", h.GetAttachment)
+
+	req := httptest.NewRequest(http.MethodGet, "/issues/2/attachments/9", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.Code)
+	}
+
+	if res.Header().Get("Content-Disposition") != `attachment; filename=app.log` {
+		t.Fatalf("unexpected Content-Disposition %q", res.Header().Get("Content-Disposition"))
+	}
+
+	if res.Body.String() != "hello" {
+		t.Fatalf("expected file content, got %q", res.Body.String())
+	}
+}
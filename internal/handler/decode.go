@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MaxBodySize limits the size of JSON request bodies. Attachments are uploaded separately.
+const MaxBodySize = 1 << 20
+
+// decodeJSON decodes the request body into v. The body must be a single JSON value
+// of at most MaxBodySize bytes without fields v does not know.
+// Unknown fields and values of the wrong type are reported as a *model.ValidationError,
+// an oversized body as *http.MaxBytesError and malformed JSON as model.ErrInvalidInput.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(v)
+	if err == nil && dec.More() {
+		err = errors.New("unexpected data after the JSON value")
+	}
+
+	var (
+		tooLarge  *http.MaxBytesError
+		typeError *json.UnmarshalTypeError
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &tooLarge):
+		return err
+	case errors.As(err, &typeError):
+		var verr model.ValidationError
+		verr.Add(typeError.Field, model.CodeInvalidType, "must be "+jsonType(typeError.Type))
+		return &verr
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no error type for unknown fields
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		var verr model.ValidationError
+		verr.Add(field, model.CodeUnknownField, "is not a known field")
+		return &verr
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("%w: request body is empty", model.ErrInvalidInput)
+	default:
+		return fmt.Errorf("%w: malformed JSON: %v", model.ErrInvalidInput, err)
+	}
+}
+
+// jsonType names the JSON type a Go type is decoded from.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// writeError maps service errors to HTTP status codes.
+// Validation errors are reported as JSON so clients can show them next to the offending fields.
+func writeError(w http.ResponseWriter, err error) {
+	var (
+		validation *model.ValidationError
+		tooLarge   *http.MaxBytesError
+	)
+	switch {
+	case errors.As(err, &validation):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string][]model.FieldError{"errors": validation.Errors})
+	case errors.As(err, &tooLarge):
+		http.Error(w, fmt.Sprintf("request body must not exceed %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, model.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, model.ErrInvalidInput):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, model.ErrUnauthorized):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, model.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, model.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type FieldHandler struct {
+	fieldService FieldService
+}
+
+func NewFieldHandler(fieldService FieldService) *FieldHandler {
+	return &FieldHandler{fieldService: fieldService}
+}
+
+func (h *FieldHandler) CreateField(w http.ResponseWriter, r *http.Request) {
+	var field model.FieldDefinition
+
+	err := decodeJSON(w, r, &field)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	id, err := h.fieldService.CreateField(r.Context(), &field)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := map[string]int{"id": id}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *FieldHandler) GetFieldByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid field ID", http.StatusBadRequest)
+		return
+	}
+
+	field, err := h.fieldService.GetFieldByID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(field)
+}
+
+func (h *FieldHandler) DeleteField(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid field ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.fieldService.DeleteField(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *FieldHandler) ListFields(w http.ResponseWriter, r *http.Request) {
+	fields, err := h.fieldService.ListFields(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(fields)
+}
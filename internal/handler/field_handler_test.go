@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MockFieldService struct {
+	CreateFunc  func(ctx context.Context, field *model.FieldDefinition) (int, error)
+	GetByIDFunc func(ctx context.Context, id int) (*model.FieldDefinition, error)
+	DeleteFunc  func(ctx context.Context, id int) error
+	ListFunc    func(ctx context.Context) ([]*model.FieldDefinition, error)
+}
+
+func (m *MockFieldService) CreateField(ctx context.Context, field *model.FieldDefinition) (int, error) {
+	return m.CreateFunc(ctx, field)
+}
+
+func (m *MockFieldService) GetFieldByID(ctx context.Context, id int) (*model.FieldDefinition, error) {
+	return m.GetByIDFunc(ctx, id)
+}
+
+func (m *MockFieldService) DeleteField(ctx context.Context, id int) error {
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *MockFieldService) ListFields(ctx context.Context) ([]*model.FieldDefinition, error) {
+	return m.ListFunc(ctx)
+}
+
+func TestCreateField(t *testing.T) {
+	var got *model.FieldDefinition
+	mockService := &MockFieldService{
+		CreateFunc: func(ctx context.Context, field *model.FieldDefinition) (int, error) {
+			got = field
+			return 7, nil
+		},
+	}
+
+	h := handler.NewFieldHandler(mockService)
+
+	body := `{"name": "environment", "type": "enum", "options": ["staging", "production"]}`
+	req := httptest.NewRequest(http.MethodPost, "/fields", bytes.NewBufferString(body))
+	res := httptest.NewRecorder()
+
+	h.CreateField(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", res.Code)
+	}
+
+	if got == nil || got.Name != "environment" || len(got.Options) != 2 {
+		t.Fatalf("expected field to be passed to service, got %v", got)
+	}
+
+	var response map[string]int
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("cannot decode response: %v", err)
+	}
+
+	if response["id"] != 7 {
+		t.Fatalf("expected id 7, got %d", response["id"])
+	}
+}
+
+func TestGetFieldByID_NotFound(t *testing.T) {
+	mockService := &MockFieldService{
+		GetByIDFunc: func(ctx context.Context, id int) (*model.FieldDefinition, error) {
+			return nil, model.ErrNotFound
+		},
+	}
+
+	h := handler.NewFieldHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/fields/{id}", h.GetFieldByID)
+
+	req := httptest.NewRequest(http.MethodGet, "/fields/3", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", res.Code)
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlotel "github.com/graph-gophers/graphql-go/trace/otel"
+)
+
+//go:embed schema.graphql
+var graphqlSchema string
+
+// maxQueryDepth keeps nested selections from fanning out into many service calls.
+const maxQueryDepth = 8
+
+type GraphQLHandler struct {
+	schema        *graphql.Schema
+	limitQuery    func(r *http.Request) error
+	limitMutation func(r *http.Request) error
+}
+
+// NewGraphQLHandler serves schema.graphql. It panics if the resolvers do not match the schema,
+// which the tests catch. Unless nil, limitQuery and limitMutation are called with the request
+// before every top-level query and mutation field, e.g. to apply the rate limits of reads and
+// writes, and the field fails with their error.
+func NewGraphQLHandler(issueService IssueService, fieldService FieldService, attachmentService AttachmentService, notificationService NotificationService, limitQuery, limitMutation func(r *http.Request) error) *GraphQLHandler {
+	root := &resolver{
+		issues:        issueService,
+		fields:        fieldService,
+		attachments:   attachmentService,
+		notifications: notificationService,
+	}
+	schema := graphql.MustParseSchema(graphqlSchema, root,
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.Tracer(gqlotel.DefaultTracer()),
+	)
+	return &GraphQLHandler{schema: schema, limitQuery: limitQuery, limitMutation: limitMutation}
+}
+
+// requestLimits are the limits of the request being executed, bound to it by the handler.
+type requestLimits struct {
+	query, mutation func() error
+}
+
+type requestLimitsKey struct{}
+
+// limitQuery applies the limit the handler put into ctx to a query field.
+func limitQuery(ctx context.Context) error {
+	limits, _ := ctx.Value(requestLimitsKey{}).(requestLimits)
+	return applyLimit(limits.query)
+}
+
+// limitMutation applies the limit the handler put into ctx to a mutation field.
+func limitMutation(ctx context.Context) error {
+	limits, _ := ctx.Value(requestLimitsKey{}).(requestLimits)
+	return applyLimit(limits.mutation)
+}
+
+func applyLimit(limit func() error) error {
+	if limit == nil {
+		return nil
+	}
+	if err := limit(); err != nil {
+		return gqlError(err)
+	}
+	return nil
+}
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// GraphQL executes a query or mutation. Errors of single fields are reported in the
+// response body next to the data that could be resolved, with status 200.
+func (h *GraphQLHandler) GraphQL(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "GraphQLHandler.GraphQL")
+	defer span.End()
+
+	var req graphqlRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Query == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	var limits requestLimits
+	if h.limitQuery != nil {
+		limits.query = func() error { return h.limitQuery(r) }
+	}
+	if h.limitMutation != nil {
+		limits.mutation = func() error { return h.limitMutation(r) }
+	}
+	ctx := context.WithValue(r.Context(), requestLimitsKey{}, limits)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
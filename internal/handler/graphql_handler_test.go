@@ -0,0 +1,355 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/ratelimit"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type graphqlResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newGraphQLHandler(issues *MockService) *handler.GraphQLHandler {
+	return newLimitedGraphQLHandler(issues, nil, nil)
+}
+
+func newLimitedGraphQLHandler(issues *MockService, limitQuery, limitMutation func(*http.Request) error) *handler.GraphQLHandler {
+	return handler.NewGraphQLHandler(
+		issues,
+		&MockFieldService{
+			ListFunc: func(ctx context.Context) ([]*model.FieldDefinition, error) {
+				return []*model.FieldDefinition{{ID: 1, Name: "customer", Type: model.FieldTypeString}}, nil
+			},
+		},
+		&MockAttachmentService{
+			BatchFunc: func(ctx context.Context, issueIDs []int) ([]*model.Attachment, error) {
+				var attachments []*model.Attachment
+				for _, id := range issueIDs {
+					attachments = append(attachments, &model.Attachment{ID: 3, IssueID: id, Filename: "trace.log", Size: 42, CreatedAt: time.Now()})
+				}
+				return attachments, nil
+			},
+		},
+		&MockNotificationService{
+			BatchFunc: func(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error) {
+				return nil, nil
+			},
+		},
+		limitQuery,
+		limitMutation,
+	)
+}
+
+func execGraphQL(t *testing.T, h *handler.GraphQLHandler, ctx context.Context, query string, variables map[string]any) graphqlResponse {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))).WithContext(ctx)
+	res := httptest.NewRecorder()
+
+	h.GraphQL(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", res.Code, res.Body)
+	}
+	var response graphqlResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func TestGraphQL_IssueWithRelations(t *testing.T) {
+	h := newGraphQLHandler(&MockService{
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			return &model.Issue{ID: id, Title: "Login fails", Status: "open", CustomFields: map[string]any{"customer": "acme"}}, nil
+		},
+	})
+
+	response := execGraphQL(t, h, context.Background(), `{
+		issue(id: 7) { id title status customFields attachments { filename url } subscriptions { email } }
+	}`, nil)
+
+	if len(response.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", response.Errors)
+	}
+	var issue struct {
+		ID           string
+		Title        string
+		CustomFields map[string]any
+		Attachments  []struct{ Filename, URL string }
+	}
+	json.Unmarshal(response.Data["issue"], &issue)
+	if issue.ID != "7" || issue.Title != "Login fails" || issue.CustomFields["customer"] != "acme" {
+		t.Fatalf("unexpected issue %+v", issue)
+	}
+	if len(issue.Attachments) != 1 || issue.Attachments[0].URL != "/issues/7/attachments/3" {
+		t.Fatalf("unexpected attachments %+v", issue.Attachments)
+	}
+}
+
+// issueStore lists issues like the repository: filtered, ordered by ID and paged.
+func issueStore(issues []*model.Issue, filters *[]model.IssueFilter) *MockService {
+	matches := func(issue *model.Issue, filter model.IssueFilter) bool {
+		return filter.Status == "" || issue.Status == filter.Status
+	}
+	return &MockService{
+		ListFunc: func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
+			*filters = append(*filters, filter)
+			var page []*model.Issue
+			for _, issue := range issues {
+				if issue.ID > filter.AfterID && matches(issue, filter) && (filter.Limit == 0 || len(page) < filter.Limit) {
+					page = append(page, issue)
+				}
+			}
+			return page, nil
+		},
+		CountFunc: func(ctx context.Context, filter model.IssueFilter) (int, error) {
+			count := 0
+			for _, issue := range issues {
+				if matches(issue, filter) {
+					count++
+				}
+			}
+			return count, nil
+		},
+	}
+}
+
+func TestGraphQL_IssuesFilterAndPagination(t *testing.T) {
+	var issues []*model.Issue
+	for id := 1; id <= 5; id++ {
+		status := "open"
+		if id == 2 {
+			status = "done"
+		}
+		issues = append(issues, &model.Issue{ID: id, Title: fmt.Sprint("Issue ", id), Status: status})
+	}
+	var filters []model.IssueFilter
+	h := newGraphQLHandler(issueStore(issues, &filters))
+
+	query := `query($after: String) {
+		issues(filter: {status: open, customFields: {customer: "acme"}}, first: 2, after: $after) {
+			nodes { id }
+			pageInfo { endCursor hasNextPage }
+			totalCount
+		}
+	}`
+
+	var ids []string
+	var after any
+	for page := 0; ; page++ {
+		response := execGraphQL(t, h, context.Background(), query, map[string]any{"after": after})
+		if len(response.Errors) > 0 {
+			t.Fatalf("unexpected errors %+v", response.Errors)
+		}
+
+		var conn struct {
+			Nodes    []struct{ ID string }
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+			TotalCount int
+		}
+		json.Unmarshal(response.Data["issues"], &conn)
+		if conn.TotalCount != 4 {
+			t.Fatalf("expected 4 open issues, got %d", conn.TotalCount)
+		}
+		for _, node := range conn.Nodes {
+			ids = append(ids, node.ID)
+		}
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		if page > 2 {
+			t.Fatal("pagination does not end")
+		}
+		after = conn.PageInfo.EndCursor
+	}
+
+	if strings.Join(ids, ",") != "1,3,4,5" {
+		t.Fatalf("expected open issues in ID order, got %v", ids)
+	}
+	// the service gets the whole filter and only loads one page at a time
+	for _, filter := range filters {
+		if filter.Status != "open" || filter.CustomFields["customer"] != "acme" || filter.Limit != 3 {
+			t.Fatalf("expected the filter and page size to reach the service, got %+v", filter)
+		}
+	}
+	if len(filters) != 2 || filters[0].AfterID != 0 || filters[1].AfterID != 3 {
+		t.Fatalf("expected two pages, the second after issue 3, got %+v", filters)
+	}
+}
+
+func TestGraphQL_IssueRelationsLoadedPerPage(t *testing.T) {
+	var issues []*model.Issue
+	for id := 1; id <= 3; id++ {
+		issues = append(issues, &model.Issue{ID: id, Title: fmt.Sprint("Issue ", id), Status: "open"})
+	}
+	var filters []model.IssueFilter
+	var attachmentCalls, subscriptionCalls [][]int
+	h := handler.NewGraphQLHandler(
+		issueStore(issues, &filters),
+		&MockFieldService{},
+		&MockAttachmentService{
+			BatchFunc: func(ctx context.Context, issueIDs []int) ([]*model.Attachment, error) {
+				attachmentCalls = append(attachmentCalls, issueIDs)
+				return []*model.Attachment{{ID: 9, IssueID: 2, Filename: "trace.log"}}, nil
+			},
+		},
+		&MockNotificationService{
+			BatchFunc: func(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error) {
+				subscriptionCalls = append(subscriptionCalls, issueIDs)
+				return []*model.EmailSubscription{{ID: 4, IssueID: 3, Email: "alice@example.com"}}, nil
+			},
+		},
+		nil, nil,
+	)
+
+	response := execGraphQL(t, h, context.Background(), `{
+		issues { nodes { id attachments { filename } subscriptions { email } } }
+	}`, nil)
+	if len(response.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", response.Errors)
+	}
+
+	if len(attachmentCalls) != 1 || len(subscriptionCalls) != 1 {
+		t.Fatalf("expected one lookup per relation, got %v and %v", attachmentCalls, subscriptionCalls)
+	}
+	if fmt.Sprint(attachmentCalls[0]) != "[1 2 3]" {
+		t.Fatalf("expected the attachments of the page to be loaded, got %v", attachmentCalls[0])
+	}
+
+	var conn struct {
+		Nodes []struct {
+			ID            string
+			Attachments   []struct{ Filename string }
+			Subscriptions []struct{ Email string }
+		}
+	}
+	json.Unmarshal(response.Data["issues"], &conn)
+	if len(conn.Nodes) != 3 || len(conn.Nodes[0].Attachments) != 0 || len(conn.Nodes[1].Attachments) != 1 || len(conn.Nodes[2].Subscriptions) != 1 {
+		t.Fatalf("expected the relations to be assigned to their issues, got %+v", conn.Nodes)
+	}
+}
+
+func TestGraphQL_CreateIssueValidationError(t *testing.T) {
+	h := newGraphQLHandler(&MockService{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			var v model.ValidationError
+			v.Add("title", model.CodeTooLong, "must be at most 200 characters")
+			return 0, &v
+		},
+	})
+
+	response := execGraphQL(t, h, context.Background(), `mutation {
+		createIssue(input: {title: "x"}) { id }
+	}`, nil)
+
+	if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "VALIDATION_FAILED" {
+		t.Fatalf("expected a validation error, got %+v", response.Errors)
+	}
+	fields, _ := response.Errors[0].Extensions["fields"].([]any)
+	if len(fields) != 1 {
+		t.Fatalf("expected the field errors in the extensions, got %+v", response.Errors[0].Extensions)
+	}
+}
+
+func TestGraphQL_MutationRequiresWriteScope(t *testing.T) {
+	h := newGraphQLHandler(&MockService{
+		DeleteFunc: func(ctx context.Context, id int) error {
+			t.Fatal("expected DeleteIssue not to be called")
+			return nil
+		},
+	})
+
+	ctx := auth.WithScopes(context.Background(), []string{model.ScopeIssuesRead})
+	response := execGraphQL(t, h, ctx, `mutation { deleteIssue(id: 1) }`, nil)
+
+	if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "FORBIDDEN" {
+		t.Fatalf("expected a forbidden error, got %+v", response.Errors)
+	}
+}
+
+func TestGraphQL_MutationRateLimit(t *testing.T) {
+	var reads, limited int
+	h := newLimitedGraphQLHandler(&MockService{
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			return &model.Issue{ID: id, Title: "Login fails", Status: "open"}, nil
+		},
+		DeleteFunc: func(ctx context.Context, id int) error {
+			t.Fatal("expected DeleteIssue not to be called")
+			return nil
+		},
+	}, func(r *http.Request) error {
+		reads++
+		return nil
+	}, func(r *http.Request) error {
+		limited++
+		return &ratelimit.ExceededError{RetryAfter: 30 * time.Second}
+	})
+
+	// queries are not writes
+	if response := execGraphQL(t, h, context.Background(), `{ issue(id: 1) { id } }`, nil); len(response.Errors) != 0 || limited != 0 || reads != 1 {
+		t.Fatalf("expected the query to pass the mutation limit, got %+v", response.Errors)
+	}
+
+	response := execGraphQL(t, h, context.Background(), `mutation { deleteIssue(id: 1) }`, nil)
+	if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "RATE_LIMITED" || limited != 1 {
+		t.Fatalf("expected a rate limit error, got %+v", response.Errors)
+	}
+	if retryAfter := response.Errors[0].Extensions["retryAfter"]; retryAfter != 30.0 {
+		t.Fatalf("expected retryAfter 30, got %v", retryAfter)
+	}
+}
+
+func TestGraphQL_QueryRateLimit(t *testing.T) {
+	h := newLimitedGraphQLHandler(&MockService{
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			t.Fatal("expected GetIssueByID not to be called")
+			return nil, nil
+		},
+	}, func(r *http.Request) error {
+		return &ratelimit.ExceededError{RetryAfter: 10 * time.Second}
+	}, nil)
+
+	response := execGraphQL(t, h, context.Background(), `{ issue(id: 1) { id } fields { name } }`, nil)
+
+	if len(response.Errors) != 2 {
+		t.Fatalf("expected both query fields to be rate limited, got %+v", response.Errors)
+	}
+	for _, e := range response.Errors {
+		if e.Extensions["code"] != "RATE_LIMITED" {
+			t.Fatalf("expected a rate limit error, got %+v", e)
+		}
+	}
+}
+
+func TestGraphQL_IssueNotFound(t *testing.T) {
+	h := newGraphQLHandler(&MockService{
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			return nil, model.ErrNotFound
+		},
+	})
+
+	response := execGraphQL(t, h, context.Background(), `{ issue(id: 1) { title } }`, nil)
+
+	if len(response.Errors) > 0 || string(response.Data["issue"]) != "null" {
+		t.Fatalf("expected null without errors, got %s %+v", response.Data["issue"], response.Errors)
+	}
+}
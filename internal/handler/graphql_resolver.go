@@ -0,0 +1,474 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/ratelimit"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// maxPageSize limits the first argument of Query.issues.
+const maxPageSize = 100
+
+// resolver is the root of schema.graphql. It resolves everything through the
+// same services as the REST handlers, so both APIs enforce the same rules.
+type resolver struct {
+	issues        IssueService
+	fields        FieldService
+	attachments   AttachmentService
+	notifications NotificationService
+}
+
+// requireScope mirrors auth.RequireScope for resolvers: a single GraphQL request
+// may read and write, so scopes are checked per field instead of per route.
+func requireScope(ctx context.Context, scope string) error {
+	if !auth.HasScope(ctx, scope) {
+		return gqlError(fmt.Errorf("%w: token lacks scope %s", model.ErrForbidden, scope))
+	}
+	return nil
+}
+
+func parseID(id graphql.ID) (int, error) {
+	n, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, gqlError(fmt.Errorf("%w: invalid ID %q", model.ErrInvalidInput, id))
+	}
+	return n, nil
+}
+
+func (r *resolver) Issue(ctx context.Context, args struct{ ID graphql.ID }) (*issueResolver, error) {
+	if err := requireScope(ctx, model.ScopeIssuesRead); err != nil {
+		return nil, err
+	}
+	if err := limitQuery(ctx); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := r.issues.GetIssueByID(ctx, id)
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, gqlError(err)
+	}
+	return r.page([]*model.Issue{issue})[0], nil
+}
+
+type issueFilterInput struct {
+	Status       *string
+	Assignee     *string
+	Reporter     *string
+	CustomFields *jsonObject
+}
+
+func (f *issueFilterInput) filter() model.IssueFilter {
+	if f == nil {
+		return model.IssueFilter{}
+	}
+	return model.IssueFilter{
+		Status:       deref(f.Status),
+		Assignee:     deref(f.Assignee),
+		Reporter:     deref(f.Reporter),
+		CustomFields: f.CustomFields.value(),
+	}
+}
+
+func (r *resolver) Issues(ctx context.Context, args struct {
+	Filter *issueFilterInput
+	First  int32
+	After  *string
+}) (*issueConnectionResolver, error) {
+	if err := requireScope(ctx, model.ScopeIssuesRead); err != nil {
+		return nil, err
+	}
+	if err := limitQuery(ctx); err != nil {
+		return nil, err
+	}
+
+	first := int(args.First)
+	if first < 0 || first > maxPageSize {
+		return nil, gqlError(fmt.Errorf("%w: first must be between 0 and %d", model.ErrInvalidInput, maxPageSize))
+	}
+
+	filter := args.Filter.filter()
+	if args.After != nil {
+		var err error
+		if filter.AfterID, err = decodeCursor(*args.After); err != nil {
+			return nil, gqlError(err)
+		}
+	}
+	// one more issue than requested tells whether there is a next page
+	filter.Limit = first + 1
+	issues, err := r.issues.ListIssues(ctx, filter)
+	if err != nil {
+		return nil, gqlError(err)
+	}
+
+	conn := &issueConnectionResolver{root: r, filter: filter, hasNextPage: len(issues) > first}
+	issues = issues[:min(first, len(issues))]
+	conn.nodes = r.page(issues)
+	if len(issues) > 0 {
+		conn.endCursor = encodeCursor(issues[len(issues)-1].ID)
+	}
+	return conn, nil
+}
+
+func (r *resolver) Fields(ctx context.Context) ([]*fieldResolver, error) {
+	if err := requireScope(ctx, model.ScopeIssuesRead); err != nil {
+		return nil, err
+	}
+	if err := limitQuery(ctx); err != nil {
+		return nil, err
+	}
+
+	fields, err := r.fields.ListFields(ctx)
+	if err != nil {
+		return nil, gqlError(err)
+	}
+	resolvers := make([]*fieldResolver, len(fields))
+	for i, field := range fields {
+		resolvers[i] = &fieldResolver{field}
+	}
+	return resolvers, nil
+}
+
+type createIssueInput struct {
+	Title        string
+	Description  *string
+	Assignee     *string
+	CustomFields *jsonObject
+}
+
+func (r *resolver) CreateIssue(ctx context.Context, args struct{ Input createIssueInput }) (*issueResolver, error) {
+	if err := requireScope(ctx, model.ScopeIssuesWrite); err != nil {
+		return nil, err
+	}
+	if err := limitMutation(ctx); err != nil {
+		return nil, err
+	}
+
+	issue := &model.Issue{
+		Title:        args.Input.Title,
+		Description:  deref(args.Input.Description),
+		Assignee:     deref(args.Input.Assignee),
+		CustomFields: args.Input.CustomFields.value(),
+	}
+	if _, err := r.issues.CreateIssue(ctx, issue); err != nil {
+		return nil, gqlError(err)
+	}
+	return r.page([]*model.Issue{issue})[0], nil
+}
+
+type updateIssueInput struct {
+	Title        string
+	Description  *string
+	Status       string
+	Assignee     *string
+	CustomFields *jsonObject
+}
+
+func (r *resolver) UpdateIssue(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input updateIssueInput
+}) (*issueResolver, error) {
+	if err := requireScope(ctx, model.ScopeIssuesWrite); err != nil {
+		return nil, err
+	}
+	if err := limitMutation(ctx); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &model.Issue{
+		ID:           id,
+		Title:        args.Input.Title,
+		Description:  deref(args.Input.Description),
+		Status:       args.Input.Status,
+		Assignee:     deref(args.Input.Assignee),
+		CustomFields: args.Input.CustomFields.value(),
+	}
+	if err := r.issues.UpdateIssue(ctx, issue); err != nil {
+		return nil, gqlError(err)
+	}
+
+	// the service fills in the acting user, the reporter is only known to the repository
+	updated, err := r.issues.GetIssueByID(ctx, id)
+	if err != nil {
+		return nil, gqlError(err)
+	}
+	return r.page([]*model.Issue{updated})[0], nil
+}
+
+func (r *resolver) DeleteIssue(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	if err := requireScope(ctx, model.ScopeIssuesWrite); err != nil {
+		return false, err
+	}
+	if err := limitMutation(ctx); err != nil {
+		return false, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.issues.DeleteIssue(ctx, id); err != nil {
+		return false, gqlError(err)
+	}
+	return true, nil
+}
+
+type issueConnectionResolver struct {
+	root        *resolver
+	filter      model.IssueFilter
+	nodes       []*issueResolver
+	endCursor   string
+	hasNextPage bool
+}
+
+func (c *issueConnectionResolver) Nodes() []*issueResolver { return c.nodes }
+func (c *issueConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{c}
+}
+
+// TotalCount is only counted when it is selected.
+func (c *issueConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	total, err := c.root.issues.CountIssues(ctx, c.filter)
+	if err != nil {
+		return 0, gqlError(err)
+	}
+	return int32(total), nil
+}
+
+type pageInfoResolver struct{ conn *issueConnectionResolver }
+
+func (p *pageInfoResolver) HasNextPage() bool { return p.conn.hasNextPage }
+func (p *pageInfoResolver) EndCursor() *string {
+	if p.conn.endCursor == "" {
+		return nil
+	}
+	return &p.conn.endCursor
+}
+
+// Cursors are opaque to clients so the ordering can change without breaking them.
+func encodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("issue:" + strconv.Itoa(id)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if s, ok := strings.CutPrefix(string(data), "issue:"); ok {
+			if id, err := strconv.Atoi(s); err == nil {
+				return id, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid cursor %q", model.ErrInvalidInput, cursor)
+}
+
+type issueResolver struct {
+	root  *resolver
+	issue *model.Issue
+	page  *issuePage
+}
+
+// issuePage loads the relations of all issues resolved together with one query
+// per relation, instead of one per issue.
+type issuePage struct {
+	ids           []int
+	attachments   relation[*model.Attachment]
+	subscriptions relation[*model.EmailSubscription]
+}
+
+// page returns the resolvers of issues that share their relation lookups.
+func (r *resolver) page(issues []*model.Issue) []*issueResolver {
+	page := &issuePage{ids: make([]int, len(issues))}
+	resolvers := make([]*issueResolver, len(issues))
+	for n, issue := range issues {
+		page.ids[n] = issue.ID
+		resolvers[n] = &issueResolver{root: r, issue: issue, page: page}
+	}
+	return resolvers
+}
+
+// relation is loaded once for a page, the first issue asking for it triggers the query.
+// Fields of list items are resolved concurrently.
+type relation[T any] struct {
+	once    sync.Once
+	byIssue map[int][]T
+	err     error
+}
+
+func (rel *relation[T]) get(issueID int, load func() ([]T, error), issueOf func(T) int) ([]T, error) {
+	rel.once.Do(func() {
+		var items []T
+		if items, rel.err = load(); rel.err != nil {
+			return
+		}
+		rel.byIssue = make(map[int][]T)
+		for _, item := range items {
+			rel.byIssue[issueOf(item)] = append(rel.byIssue[issueOf(item)], item)
+		}
+	})
+	return rel.byIssue[issueID], rel.err
+}
+
+func (i *issueResolver) ID() graphql.ID      { return graphql.ID(strconv.Itoa(i.issue.ID)) }
+func (i *issueResolver) Title() string       { return i.issue.Title }
+func (i *issueResolver) Description() string { return i.issue.Description }
+func (i *issueResolver) Status() string      { return i.issue.Status }
+func (i *issueResolver) Reporter() string    { return i.issue.Reporter }
+func (i *issueResolver) Assignee() string    { return i.issue.Assignee }
+func (i *issueResolver) UpdatedBy() string   { return i.issue.UpdatedBy }
+func (i *issueResolver) CustomFields() *jsonObject {
+	if i.issue.CustomFields == nil {
+		return nil
+	}
+	return (*jsonObject)(&i.issue.CustomFields)
+}
+
+func (i *issueResolver) Attachments(ctx context.Context) ([]*attachmentResolver, error) {
+	if err := requireScope(ctx, model.ScopeIssuesRead); err != nil {
+		return nil, err
+	}
+	attachments, err := i.page.attachments.get(i.issue.ID,
+		func() ([]*model.Attachment, error) {
+			return i.root.attachments.ListAttachmentsForIssues(ctx, i.page.ids)
+		},
+		func(a *model.Attachment) int { return a.IssueID },
+	)
+	if err != nil {
+		return nil, gqlError(err)
+	}
+	resolvers := make([]*attachmentResolver, len(attachments))
+	for n, attachment := range attachments {
+		resolvers[n] = &attachmentResolver{attachment}
+	}
+	return resolvers, nil
+}
+
+func (i *issueResolver) Subscriptions(ctx context.Context) ([]*subscriptionResolver, error) {
+	if err := requireScope(ctx, model.ScopeIssuesRead); err != nil {
+		return nil, err
+	}
+	subscriptions, err := i.page.subscriptions.get(i.issue.ID,
+		func() ([]*model.EmailSubscription, error) {
+			return i.root.notifications.ListSubscriptionsForIssues(ctx, i.page.ids)
+		},
+		func(s *model.EmailSubscription) int { return s.IssueID },
+	)
+	if err != nil {
+		return nil, gqlError(err)
+	}
+	resolvers := make([]*subscriptionResolver, len(subscriptions))
+	for n, subscription := range subscriptions {
+		resolvers[n] = &subscriptionResolver{subscription}
+	}
+	return resolvers, nil
+}
+
+type attachmentResolver struct{ a *model.Attachment }
+
+func (a *attachmentResolver) ID() graphql.ID          { return graphql.ID(strconv.Itoa(a.a.ID)) }
+func (a *attachmentResolver) Filename() string        { return a.a.Filename }
+func (a *attachmentResolver) ContentType() string     { return a.a.ContentType }
+func (a *attachmentResolver) Size() float64           { return float64(a.a.Size) }
+func (a *attachmentResolver) CreatedAt() graphql.Time { return graphql.Time{Time: a.a.CreatedAt} }
+func (a *attachmentResolver) URL() string {
+	return fmt.Sprintf("/issues/%d/attachments/%d", a.a.IssueID, a.a.ID)
+}
+
+type subscriptionResolver struct{ s *model.EmailSubscription }
+
+func (s *subscriptionResolver) ID() graphql.ID          { return graphql.ID(strconv.Itoa(s.s.ID)) }
+func (s *subscriptionResolver) Email() string           { return s.s.Email }
+func (s *subscriptionResolver) Digest() bool            { return s.s.Digest }
+func (s *subscriptionResolver) CreatedAt() graphql.Time { return graphql.Time{Time: s.s.CreatedAt} }
+
+type fieldResolver struct{ f *model.FieldDefinition }
+
+func (f *fieldResolver) ID() graphql.ID    { return graphql.ID(strconv.Itoa(f.f.ID)) }
+func (f *fieldResolver) Name() string      { return f.f.Name }
+func (f *fieldResolver) Type() string      { return f.f.Type }
+func (f *fieldResolver) Options() []string { return f.f.Options }
+func (f *fieldResolver) Required() bool    { return f.f.Required }
+
+// jsonObject implements the JSON scalar.
+type jsonObject map[string]any
+
+func (jsonObject) ImplementsGraphQLType(name string) bool { return name == "JSON" }
+
+func (j *jsonObject) UnmarshalGraphQL(input any) error {
+	m, ok := input.(map[string]any)
+	if !ok {
+		return fmt.Errorf("JSON must be an object, got %T", input)
+	}
+	*j = m
+	return nil
+}
+
+func (j *jsonObject) value() map[string]any {
+	if j == nil {
+		return nil
+	}
+	return *j
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// resolverError carries the error class in the extensions of a GraphQL error,
+// the counterpart of the status codes writeError picks for REST.
+type resolverError struct {
+	err error
+}
+
+func gqlError(err error) error {
+	return &resolverError{err}
+}
+
+func (e *resolverError) Error() string { return e.err.Error() }
+func (e *resolverError) Unwrap() error { return e.err }
+
+func (e *resolverError) Extensions() map[string]any {
+	var validation *model.ValidationError
+	var exceeded *ratelimit.ExceededError
+	switch {
+	case errors.As(e.err, &validation):
+		return map[string]any{"code": "VALIDATION_FAILED", "fields": validation.Errors}
+	case errors.As(e.err, &exceeded):
+		return map[string]any{"code": "RATE_LIMITED", "retryAfter": int(math.Ceil(exceeded.RetryAfter.Seconds()))}
+	case errors.Is(e.err, model.ErrNotFound):
+		return map[string]any{"code": "NOT_FOUND"}
+	case errors.Is(e.err, model.ErrInvalidInput):
+		return map[string]any{"code": "BAD_USER_INPUT"}
+	case errors.Is(e.err, model.ErrUnauthorized):
+		return map[string]any{"code": "UNAUTHENTICATED"}
+	case errors.Is(e.err, model.ErrForbidden):
+		return map[string]any{"code": "FORBIDDEN"}
+	case errors.Is(e.err, model.ErrConflict):
+		return map[string]any{"code": "CONFLICT"}
+	default:
+		return map[string]any{"code": "INTERNAL"}
+	}
+}
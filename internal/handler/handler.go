@@ -5,8 +5,18 @@ import (
 	"encoding/json"
 	"Go-IssueTracker-API/internal/model"
 	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// customFieldParamPrefix marks query parameters that filter issues by custom field value,
+// e.g. /issues?field.customer=acme
+const customFieldParamPrefix = "field."
+
+var tracer = otel.Tracer("Go-IssueTracker-API/internal/handler")
+
 type Handler struct {
 	issueService IssueService
 }
@@ -15,18 +25,27 @@ func NewHandler(issueService IssueService) *Handler {
 	return &Handler{issueService: issueService}
 }
 
+// startSpan starts a span for a handler method and returns the request carrying it.
+func startSpan(r *http.Request, name string) (*http.Request, trace.Span) {
+	ctx, span := tracer.Start(r.Context(), name)
+	return r.WithContext(ctx), span
+}
+
 func (h* Handler) CreateIssue(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Handler.CreateIssue")
+	defer span.End()
+
 	var issue model.Issue
 
-	err := json.NewDecoder(r.Body).Decode(&issue) // парсим тело запроса в структуру Issue
+	err := decodeJSON(w, r, &issue) // парсим тело запроса в структуру Issue
 	if err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
 	id, err := h.issueService.CreateIssue(r.Context(), &issue) // вызываем сервис для создания новой issue
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -38,6 +57,9 @@ func (h* Handler) CreateIssue(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetIssueByID(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Handler.GetIssueByID")
+	defer span.End()
+
 	idStr := r.PathValue("id") // парсим ID из URL
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -47,7 +69,7 @@ func (h *Handler) GetIssueByID(w http.ResponseWriter, r *http.Request) {
 
 	issue, err := h.issueService.GetIssueByID(r.Context(), id) // вызываем сервис для получения issue по ID
 	if err != nil {
-		http.Error(w, "issue not found", http.StatusNotFound)
+		writeError(w, err)
 		return
 	}
 
@@ -56,6 +78,9 @@ func (h *Handler) GetIssueByID(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) UpdateIssue(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Handler.UpdateIssue")
+	defer span.End()
+
 	idStr := r.PathValue("id") // парсим ID из URL
 	id, err := strconv.Atoi(idStr) 
 	if err != nil {
@@ -64,16 +89,16 @@ func (h *Handler) UpdateIssue(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var issue model.Issue
-	err = json.NewDecoder(r.Body).Decode(&issue) // парсим тело запроса в структуру Issue
+	err = decodeJSON(w, r, &issue) // парсим тело запроса в структуру Issue
 	if err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
 	issue.ID = id // устанавливаем ID из URL в структуру Issue
 	err = h.issueService.UpdateIssue(r.Context(), &issue) // вызываем сервис для обновления issue
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -81,6 +106,9 @@ func (h *Handler) UpdateIssue(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) DeleteIssue(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Handler.DeleteIssue")
+	defer span.End()
+
 	idStr := r.PathValue("id") // парсим ID из URL
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -90,7 +118,7 @@ func (h *Handler) DeleteIssue(w http.ResponseWriter, r *http.Request) {
 
 	err = h.issueService.DeleteIssue(r.Context(), id) // вызываем сервис для удаления issue по ID
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	
@@ -98,9 +126,24 @@ func (h *Handler) DeleteIssue(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) ListIssues(w http.ResponseWriter, r *http.Request) {
-	issues, err := h.issueService.ListIssues(r.Context()) // вызываем сервис для получения списка всех issues
+	r, span := startSpan(r, "Handler.ListIssues")
+	defer span.End()
+
+	var filter model.IssueFilter
+	for key, values := range r.URL.Query() { // собираем фильтры по кастомным полям
+		name, ok := strings.CutPrefix(key, customFieldParamPrefix)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if filter.CustomFields == nil {
+			filter.CustomFields = make(map[string]any)
+		}
+		filter.CustomFields[name] = values[0]
+	}
+
+	issues, err := h.issueService.ListIssues(r.Context(), filter) // вызываем сервис для получения списка issues
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
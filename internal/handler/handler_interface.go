@@ -2,6 +2,9 @@ package handler
 
 import (
 	"context"
+	"io"
+	"Go-IssueTracker-API/internal/eventbus"
+	"Go-IssueTracker-API/internal/presence"
 	"Go-IssueTracker-API/internal/model"
 )
 
@@ -10,5 +13,70 @@ type IssueService interface {
 	GetIssueByID(ctx context.Context, id int) (*model.Issue, error)
 	UpdateIssue(ctx context.Context, issue *model.Issue) error
 	DeleteIssue(ctx context.Context, id int) error
-	ListIssues(ctx context.Context) ([]*model.Issue, error)
-}
\ No newline at end of file
+	ListIssues(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error)
+	CountIssues(ctx context.Context, filter model.IssueFilter) (int, error)
+}
+
+type FieldService interface {
+	CreateField(ctx context.Context, field *model.FieldDefinition) (int, error)
+	GetFieldByID(ctx context.Context, id int) (*model.FieldDefinition, error)
+	DeleteField(ctx context.Context, id int) error
+	ListFields(ctx context.Context) ([]*model.FieldDefinition, error)
+}
+
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, attachment *model.Attachment, content io.Reader) (int, error)
+	GetAttachment(ctx context.Context, issueID, id int) (*model.Attachment, io.ReadCloser, error)
+	ListAttachments(ctx context.Context, issueID int) ([]*model.Attachment, error)
+	ListAttachmentsForIssues(ctx context.Context, issueIDs []int) ([]*model.Attachment, error)
+}
+
+type WatcherService interface {
+	WatchIssue(ctx context.Context, issueID int) error
+	UnwatchIssue(ctx context.Context, issueID int) error
+	ListWatchedIssues(ctx context.Context) ([]*model.Issue, error)
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) (int, error)
+	GetWebhook(ctx context.Context, id int) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, id int) error
+	ListWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	ListDeliveries(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error)
+	ListDeliveryAttempts(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error)
+}
+
+type NotificationService interface {
+	Subscribe(ctx context.Context, subscription *model.EmailSubscription) (int, error)
+	Unsubscribe(ctx context.Context, issueID, id int) error
+	ListSubscriptions(ctx context.Context, issueID int) ([]*model.EmailSubscription, error)
+	ListSubscriptionsForIssues(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error)
+}
+
+type TokenService interface {
+	CreateToken(ctx context.Context, token *model.APIToken) (int, error)
+	ListTokens(ctx context.Context) ([]*model.APIToken, error)
+	RevokeToken(ctx context.Context, id int) error
+}
+
+type MemberService interface {
+	SetMember(ctx context.Context, member *model.Member) error
+	RemoveMember(ctx context.Context, user string) error
+	ListMembers(ctx context.Context) ([]*model.Member, error)
+}
+
+// EventStream delivers the issue events of the caller's tenant, eventbus.Bus implements it.
+type EventStream interface {
+	Resume(ctx context.Context, lastEventID string) (*eventbus.Subscription, bool)
+}
+
+// PresenceTracker records who is viewing which issue, presence.Tracker implements it.
+type PresenceTracker interface {
+	Join(ctx context.Context) *presence.Session
+}
+
+// Pinger checks that the database is reachable, *sql.DB implements it.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
@@ -6,8 +6,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"github.com/go-chi/chi/v5"
 )
@@ -17,7 +20,8 @@ type MockService struct {
 	GetByIDFunc func(ctx context.Context, id int) (*model.Issue, error)
 	UpdateFunc  func(ctx context.Context, issue *model.Issue) error
 	DeleteFunc  func(ctx context.Context, id int) error
-	ListFunc    func(ctx context.Context) ([]*model.Issue, error)
+	ListFunc    func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error)
+	CountFunc   func(ctx context.Context, filter model.IssueFilter) (int, error)
 }
 
 func (m *MockService) CreateIssue(ctx context.Context, issue *model.Issue) (int, error) {
@@ -36,8 +40,12 @@ func (m *MockService) DeleteIssue(ctx context.Context, id int) error {
 	return m.DeleteFunc(ctx, id)
 }
 
-func (m *MockService) ListIssues(ctx context.Context) ([]*model.Issue, error) {
-	return m.ListFunc(ctx)
+func (m *MockService) ListIssues(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
+	return m.ListFunc(ctx, filter)
+}
+
+func (m *MockService) CountIssues(ctx context.Context, filter model.IssueFilter) (int, error) {
+	return m.CountFunc(ctx, filter)
 }
 
 func TestCreateIssue(t *testing.T) {
@@ -131,6 +139,38 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
+func TestGetByID_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", model.ErrNotFound, http.StatusNotFound},
+		{"forbidden", model.ErrForbidden, http.StatusForbidden},
+		{"database failure", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.NewHandler(&MockService{
+				GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+					return nil, tt.err
+				},
+			})
+			r := chi.NewRouter()
+			r.Get("/issue/{id}", h.GetIssueByID)
+
+			req := httptest.NewRequest(http.MethodGet, "/issue/1", nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, res.Code)
+			}
+		})
+	}
+}
+
 func TestUpdateIssue(t *testing.T) {
 	called := false
 
@@ -204,7 +244,7 @@ func TestListIssues(t *testing.T) {
 	called := false
 
 	mockService := &MockService{
-		ListFunc: func(ctx context.Context) ([]*model.Issue, error) {
+		ListFunc: func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
 			called = true
 			return []*model.Issue{
 				{
@@ -244,4 +284,130 @@ func TestListIssues(t *testing.T) {
 	if len(response) != 1 || response[0].ID != 1 {
 		t.Fatalf("expected valid issues list, got %v", response)
 	}
-}
\ No newline at end of file
+}
+
+func TestListIssues_CustomFieldFilter(t *testing.T) {
+	var got model.IssueFilter
+
+	mockService := &MockService{
+		ListFunc: func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
+			got = filter
+			return []*model.Issue{}, nil
+		},
+	}
+
+	h := handler.NewHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/issues", h.ListIssues)
+
+	req := httptest.NewRequest(http.MethodGet, "/issues?field.customer=acme&other=1", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.Code)
+	}
+
+	if len(got.CustomFields) != 1 || got.CustomFields["customer"] != "acme" {
+		t.Fatalf("expected customer filter, got %v", got.CustomFields)
+	}
+}
+
+func TestCreateIssue_InvalidInput(t *testing.T) {
+	mockService := &MockService{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			return 0, fmt.Errorf("%w: title is required", model.ErrInvalidInput)
+		},
+	}
+
+	h := handler.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/issues", bytes.NewBufferString(`{}`))
+	res := httptest.NewRecorder()
+
+	h.CreateIssue(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", res.Code)
+	}
+}
+
+func TestCreateIssue_ValidationErrors(t *testing.T) {
+	mockService := &MockService{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			var v model.ValidationError
+			v.Add("title", model.CodeTooLong, "must be at most 200 characters")
+			v.Add("assignee", model.CodeInvalidCharacters, "must be valid UTF-8 without spaces or control characters")
+			return 0, &v
+		},
+	}
+
+	h := handler.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/issues", bytes.NewBufferString(`{"title":"x","assignee":"a b"}`))
+	res := httptest.NewRecorder()
+
+	h.CreateIssue(res, req)
+
+	if res.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", res.Code)
+	}
+
+	var body struct {
+		Errors []model.FieldError `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body.Errors) != 2 || body.Errors[0].Field != "title" || body.Errors[1].Code != model.CodeInvalidCharacters {
+		t.Fatalf("unexpected errors %+v", body.Errors)
+	}
+}
+
+func TestCreateIssue_RejectsBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  int
+		wantField string
+	}{
+		{"unknown field", `{"title":"Test","priority":"high"}`, http.StatusUnprocessableEntity, "priority"},
+		{"wrong type", `{"title":42}`, http.StatusUnprocessableEntity, "title"},
+		{"malformed", `{"title":`, http.StatusBadRequest, ""},
+		{"trailing data", `{"title":"Test"} {}`, http.StatusBadRequest, ""},
+		{"too large", `{"description":"` + strings.Repeat("a", handler.MaxBodySize) + `"}`, http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockService{
+				CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+					t.Fatal("expected the body to be rejected before the service")
+					return 0, nil
+				},
+			}
+
+			h := handler.NewHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/issues", strings.NewReader(tt.body))
+			res := httptest.NewRecorder()
+
+			h.CreateIssue(res, req)
+
+			if res.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, res.Code, res.Body)
+			}
+			if tt.wantField == "" {
+				return
+			}
+
+			var body struct {
+				Errors []model.FieldError `json:"errors"`
+			}
+			json.NewDecoder(res.Body).Decode(&body)
+			if len(body.Errors) != 1 || body.Errors[0].Field != tt.wantField {
+				t.Fatalf("expected an error for %q, got %+v", tt.wantField, body.Errors)
+			}
+		})
+	}
+}
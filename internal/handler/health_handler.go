@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/version"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// readyTimeout bounds the database ping of a readiness probe.
+const readyTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	db Pinger
+}
+
+func NewHealthHandler(db Pinger) *HealthHandler {
+	return &HealthHandler{db: db}
+}
+
+type checkResult struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+// Healthz reports that the process is up and serving requests. It checks no dependencies,
+// so a database outage does not get the process restarted.
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// Readyz reports whether requests can be served, which needs the database.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.db.PingContext(ctx)
+	check := checkResult{
+		Status:    "ok",
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	response := healthResponse{Status: "ok", Checks: map[string]checkResult{"database": check}}
+	status := http.StatusOK
+	if err != nil {
+		check.Status = "unavailable"
+		check.Error = err.Error()
+		response.Checks["database"] = check
+		response.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+
+	writeHealth(w, status, response)
+}
+
+// Version reports the build of the running server.
+func (h *HealthHandler) Version(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version.Get())
+}
+
+func writeHealth(w http.ResponseWriter, status int, response healthResponse) {
+	// probes must always see the current state
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockPinger struct {
+	PingFunc func(ctx context.Context) error
+}
+
+func (m *MockPinger) PingContext(ctx context.Context) error {
+	return m.PingFunc(ctx)
+}
+
+type healthBody struct {
+	Status string `json:"status"`
+	Checks map[string]struct {
+		Status    string  `json:"status"`
+		LatencyMS float64 `json:"latency_ms"`
+		Error     string  `json:"error"`
+	} `json:"checks"`
+}
+
+func TestHealthz(t *testing.T) {
+	h := handler.NewHealthHandler(&MockPinger{PingFunc: func(ctx context.Context) error {
+		t.Fatal("liveness must not check the database")
+		return nil
+	}})
+
+	res := httptest.NewRecorder()
+	h.Healthz(res, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		pingErr    error
+		wantCode   int
+		wantStatus string
+	}{
+		{"database up", nil, http.StatusOK, "ok"},
+		{"database down", errors.New("connection refused"), http.StatusServiceUnavailable, "unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.NewHealthHandler(&MockPinger{PingFunc: func(ctx context.Context) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Fatal("expected the ping to be bounded by a deadline")
+				}
+				return tt.pingErr
+			}})
+
+			res := httptest.NewRecorder()
+			h.Readyz(res, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if res.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d", tt.wantCode, res.Code)
+			}
+
+			var body healthBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			db := body.Checks["database"]
+			if body.Status != tt.wantStatus || db.Status != tt.wantStatus {
+				t.Fatalf("unexpected body %+v", body)
+			}
+			if tt.pingErr != nil && db.Error != tt.pingErr.Error() {
+				t.Fatalf("expected the ping error in the body, got %q", db.Error)
+			}
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	h := handler.NewHealthHandler(&MockPinger{})
+
+	res := httptest.NewRecorder()
+	h.Version(res, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var body map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body["version"] != "dev" || body["go_version"] == "" {
+		t.Fatalf("unexpected build info %v", body)
+	}
+}
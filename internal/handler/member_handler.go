@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"encoding/json"
+	"net/http"
+)
+
+type MemberHandler struct {
+	memberService MemberService
+}
+
+func NewMemberHandler(memberService MemberService) *MemberHandler {
+	return &MemberHandler{memberService: memberService}
+}
+
+func (h *MemberHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	var member model.Member
+
+	err := decodeJSON(w, r, &member)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	member.User = r.PathValue("user")
+	err = h.memberService.SetMember(r.Context(), &member)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(member)
+}
+
+func (h *MemberHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	err := h.memberService.RemoveMember(r.Context(), r.PathValue("user"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MemberHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	members, err := h.memberService.ListMembers(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(members)
+}
@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockMemberService struct {
+	SetFunc    func(ctx context.Context, member *model.Member) error
+	RemoveFunc func(ctx context.Context, user string) error
+	ListFunc   func(ctx context.Context) ([]*model.Member, error)
+}
+
+func (m *MockMemberService) SetMember(ctx context.Context, member *model.Member) error {
+	return m.SetFunc(ctx, member)
+}
+
+func (m *MockMemberService) RemoveMember(ctx context.Context, user string) error {
+	return m.RemoveFunc(ctx, user)
+}
+
+func (m *MockMemberService) ListMembers(ctx context.Context) ([]*model.Member, error) {
+	return m.ListFunc(ctx)
+}
+
+func TestSetMember(t *testing.T) {
+	var got model.Member
+	mockService := &MockMemberService{
+		SetFunc: func(ctx context.Context, member *model.Member) error {
+			got = *member
+			return nil
+		},
+	}
+
+	h := handler.NewMemberHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPut, "/members/carl", bytes.NewReader([]byte(`{"role": "reporter"}`)))
+	req.SetPathValue("user", "carl")
+	res := httptest.NewRecorder()
+
+	h.SetMember(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.Code)
+	}
+	if got.User != "carl" || got.Role != model.RoleReporter {
+		t.Fatalf("unexpected member passed to service: %+v", got)
+	}
+}
+
+func TestSetMember_Forbidden(t *testing.T) {
+	mockService := &MockMemberService{
+		SetFunc: func(ctx context.Context, member *model.Member) error {
+			return model.ErrForbidden
+		},
+	}
+
+	h := handler.NewMemberHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPut, "/members/carl", bytes.NewReader([]byte(`{"role": "admin"}`)))
+	req.SetPathValue("user", "carl")
+	res := httptest.NewRecorder()
+
+	h.SetMember(res, req)
+
+	if res.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", res.Code)
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type NotificationHandler struct {
+	notificationService NotificationService
+}
+
+func NewNotificationHandler(notificationService NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+func (h *NotificationHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	issueID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid issue ID", http.StatusBadRequest)
+		return
+	}
+
+	var subscription model.EmailSubscription
+	err = decodeJSON(w, r, &subscription)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	subscription.IssueID = issueID
+	id, err := h.notificationService.Subscribe(r.Context(), &subscription)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := map[string]int{"id": id}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *NotificationHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	issueID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid issue ID", http.StatusBadRequest)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("subscriptionID"))
+	if err != nil {
+		http.Error(w, "Invalid subscription ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.notificationService.Unsubscribe(r.Context(), issueID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *NotificationHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	issueID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid issue ID", http.StatusBadRequest)
+		return
+	}
+
+	subscriptions, err := h.notificationService.ListSubscriptions(r.Context(), issueID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscriptions)
+}
@@ -0,0 +1,63 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MockNotificationService struct {
+	SubscribeFunc   func(ctx context.Context, subscription *model.EmailSubscription) (int, error)
+	UnsubscribeFunc func(ctx context.Context, issueID, id int) error
+	ListFunc        func(ctx context.Context, issueID int) ([]*model.EmailSubscription, error)
+	BatchFunc       func(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error)
+}
+
+func (m *MockNotificationService) Subscribe(ctx context.Context, subscription *model.EmailSubscription) (int, error) {
+	return m.SubscribeFunc(ctx, subscription)
+}
+
+func (m *MockNotificationService) Unsubscribe(ctx context.Context, issueID, id int) error {
+	return m.UnsubscribeFunc(ctx, issueID, id)
+}
+
+func (m *MockNotificationService) ListSubscriptions(ctx context.Context, issueID int) ([]*model.EmailSubscription, error) {
+	return m.ListFunc(ctx, issueID)
+}
+
+func (m *MockNotificationService) ListSubscriptionsForIssues(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error) {
+	return m.BatchFunc(ctx, issueIDs)
+}
+
+func TestSubscribe(t *testing.T) {
+	var got model.EmailSubscription
+	mockService := &MockNotificationService{
+		SubscribeFunc: func(ctx context.Context, subscription *model.EmailSubscription) (int, error) {
+			got = *subscription
+			return 1, nil
+		},
+	}
+
+	h := handler.NewNotificationHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/issues/{id}/subscriptions", h.Subscribe)
+
+	body := `{"email": "alice@example.com", "digest": true}`
+	req := httptest.NewRequest(http.MethodPost, "/issues/4/subscriptions", bytes.NewBufferString(body))
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", res.Code)
+	}
+
+	if got.IssueID != 4 || got.Email != "alice@example.com" || !got.Digest {
+		t.Fatalf("unexpected subscription %+v", got)
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/model"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// streamRetry tells EventSource clients how long to wait before reconnecting, in milliseconds.
+const streamRetry = 3000
+
+// StreamHandler pushes issue changes to clients as Server-Sent Events.
+type StreamHandler struct {
+	events    EventStream
+	heartbeat time.Duration
+}
+
+// NewStreamHandler returns a handler sending a comment every heartbeat,
+// so proxies do not close idle streams.
+func NewStreamHandler(events EventStream, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{events: events, heartbeat: heartbeat}
+}
+
+// streamFilter selects the events a client asked for. An event matches when
+// the issue matches before or after the change, so clients also learn about
+// issues leaving the filter.
+type streamFilter struct {
+	status       string
+	customFields map[string]string
+}
+
+func (f streamFilter) matches(event model.IssueEvent) bool {
+	if f.status == "" && len(f.customFields) == 0 {
+		return true
+	}
+	return f.matchesIssue(event.Issue) || f.matchesIssue(event.Previous)
+}
+
+func (f streamFilter) matchesIssue(issue *model.Issue) bool {
+	if issue == nil {
+		return false
+	}
+	if f.status != "" && issue.Status != f.status {
+		return false
+	}
+	for name, want := range f.customFields {
+		value, ok := issue.CustomFields[name]
+		if !ok || fmt.Sprint(value) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// Stream sends the changes of the caller's tenant, optionally filtered by status and custom
+// fields like ListIssues, until the client disconnects. A client reconnecting with the
+// Last-Event-ID header first receives the events it missed, or a reset event when they
+// are no longer known and it has to reload the issues.
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := streamFilter{status: query.Get("status")}
+	if filter.status != "" && !slices.Contains(model.IssueStatuses, filter.status) {
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+	for key, values := range query {
+		name, ok := strings.CutPrefix(key, customFieldParamPrefix)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if filter.customFields == nil {
+			filter.customFields = make(map[string]string)
+		}
+		filter.customFields[name] = values[0]
+	}
+
+	// EventSource cannot set headers on the first connection, so the ID may come as a parameter
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("lastEventId")
+	}
+
+	// the stream outlives the write timeout of the server
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sub, complete := h.events.Resume(r.Context(), lastEventID)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // keep nginx from buffering the stream
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry)
+	if !complete {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-sub.Events():
+			if !ok {
+				// dropped or shutting down, the client reconnects with the last ID it received
+				if sub.Dropped() {
+					logging.FromContext(r.Context()).Warn("Event stream client fell behind")
+				}
+				return
+			}
+			if !filter.matches(event.IssueEvent) {
+				continue
+			}
+			data, err := json.Marshal(event.IssueEvent)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
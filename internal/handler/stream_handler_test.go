@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/eventbus"
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseEvent struct {
+	id, name, data string
+}
+
+// sseClient reads the events of a stream, skipping comments and the retry field.
+type sseClient struct {
+	t       *testing.T
+	scanner *bufio.Scanner
+}
+
+func openStream(t *testing.T, srv *httptest.Server, query, lastEventID string) *sseClient {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/issues/stream"+query, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	return &sseClient{t: t, scanner: bufio.NewScanner(res.Body)}
+}
+
+func (c *sseClient) next() sseEvent {
+	c.t.Helper()
+
+	var event sseEvent
+	for c.scanner.Scan() {
+		line := c.scanner.Text()
+		if line == "" {
+			if event.name != "" {
+				return event
+			}
+			continue
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		switch field {
+		case "id":
+			event.id = value
+		case "event":
+			event.name = value
+		case "data":
+			event.data = value
+		}
+	}
+	c.t.Fatalf("stream ended: %v", c.scanner.Err())
+	return event
+}
+
+func newStreamServer(t *testing.T, bus *eventbus.Bus) *httptest.Server {
+	h := handler.NewStreamHandler(bus, time.Hour)
+	srv := httptest.NewServer(http.HandlerFunc(h.Stream))
+	t.Cleanup(srv.Close)
+	t.Cleanup(bus.Close) // let the streams end before the server waits for them
+	return srv
+}
+
+func TestStream_SendsEvents(t *testing.T) {
+	bus := eventbus.New(8, 0)
+	srv := newStreamServer(t, bus)
+	client := openStream(t, srv, "", "")
+
+	// the handler subscribes before it sends the response headers
+	bus.Publish(context.Background(), model.IssueEvent{
+		Type:    model.EventIssueCreated,
+		IssueID: 7,
+		Issue:   &model.Issue{ID: 7, Title: "Login fails", Status: "open"},
+	})
+
+	event := client.next()
+	if event.name != model.EventIssueCreated || event.id == "" || !strings.Contains(event.data, `"issue_id":7`) {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestStream_Filter(t *testing.T) {
+	bus := eventbus.New(8, 0)
+	srv := newStreamServer(t, bus)
+	client := openStream(t, srv, "?status=done&field.project=board", "")
+
+	ctx := context.Background()
+	board := map[string]any{"project": "board"}
+	bus.Publish(ctx, model.IssueEvent{
+		Type:    model.EventIssueCreated,
+		IssueID: 1,
+		Issue:   &model.Issue{ID: 1, Status: "done", CustomFields: map[string]any{"project": "other"}},
+	})
+	bus.Publish(ctx, model.IssueEvent{
+		Type:    model.EventIssueCreated,
+		IssueID: 2,
+		Issue:   &model.Issue{ID: 2, Status: "open", CustomFields: board},
+	})
+	// leaving the filter is reported as well
+	bus.Publish(ctx, model.IssueEvent{
+		Type:     model.EventIssueStatusChanged,
+		IssueID:  3,
+		Issue:    &model.Issue{ID: 3, Status: "open", CustomFields: board},
+		Previous: &model.Issue{ID: 3, Status: "done", CustomFields: board},
+	})
+
+	if event := client.next(); !strings.Contains(event.data, `"issue_id":3`) {
+		t.Fatalf("expected only the event of issue 3, got %+v", event)
+	}
+}
+
+func TestStream_InvalidStatus(t *testing.T) {
+	h := handler.NewStreamHandler(eventbus.New(0, 0), time.Hour)
+	req := httptest.NewRequest(http.MethodGet, "/issues/stream?status=closed", nil)
+	res := httptest.NewRecorder()
+
+	h.Stream(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", res.Code)
+	}
+}
+
+func TestStream_ResumeWithLastEventID(t *testing.T) {
+	bus := eventbus.New(8, 0)
+	srv := newStreamServer(t, bus)
+	first := openStream(t, srv, "", "")
+
+	ctx := context.Background()
+	bus.Publish(ctx, model.IssueEvent{Type: model.EventIssueCreated, IssueID: 1})
+	bus.Publish(ctx, model.IssueEvent{Type: model.EventIssueDeleted, IssueID: 1})
+	received := first.next()
+
+	resumed := openStream(t, srv, "", received.id)
+	if event := resumed.next(); event.name != model.EventIssueDeleted {
+		t.Fatalf("expected the missed event, got %+v", event)
+	}
+
+	// an ID from before a restart cannot be resumed
+	reset := openStream(t, srv, "", "0-1")
+	if event := reset.next(); event.name != "reset" {
+		t.Fatalf("expected a reset event, got %+v", event)
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type TokenHandler struct {
+	tokenService TokenService
+}
+
+func NewTokenHandler(tokenService TokenService) *TokenHandler {
+	return &TokenHandler{tokenService: tokenService}
+}
+
+func (h *TokenHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	var token model.APIToken
+
+	err := decodeJSON(w, r, &token)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	id, err := h.tokenService.CreateToken(r.Context(), &token)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// the token is only returned once, on creation
+	response := map[string]any{"id": id, "token": token.Token}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *TokenHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.tokenService.ListTokens(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+func (h *TokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid token ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.tokenService.RevokeToken(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockTokenService struct {
+	CreateFunc func(ctx context.Context, token *model.APIToken) (int, error)
+	ListFunc   func(ctx context.Context) ([]*model.APIToken, error)
+	RevokeFunc func(ctx context.Context, id int) error
+}
+
+func (m *MockTokenService) CreateToken(ctx context.Context, token *model.APIToken) (int, error) {
+	return m.CreateFunc(ctx, token)
+}
+
+func (m *MockTokenService) ListTokens(ctx context.Context) ([]*model.APIToken, error) {
+	return m.ListFunc(ctx)
+}
+
+func (m *MockTokenService) RevokeToken(ctx context.Context, id int) error {
+	return m.RevokeFunc(ctx, id)
+}
+
+func TestCreateToken(t *testing.T) {
+	var got model.APIToken
+	mockService := &MockTokenService{
+		CreateFunc: func(ctx context.Context, token *model.APIToken) (int, error) {
+			got = *token
+			token.Token = "itk_generated"
+			return 4, nil
+		},
+	}
+
+	h := handler.NewTokenHandler(mockService)
+
+	body := []byte(`{"name": "ci", "scopes": ["issues:write"], "expires_at": "2030-01-01T00:00:00Z"}`)
+	req := httptest.NewRequest(http.MethodPost, "/tokens", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	h.CreateToken(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", res.Code)
+	}
+	if got.Name != "ci" || len(got.Scopes) != 1 || got.ExpiresAt == nil {
+		t.Fatalf("unexpected token passed to service: %+v", got)
+	}
+
+	var response map[string]any
+	json.NewDecoder(res.Body).Decode(&response)
+	if response["token"] != "itk_generated" || response["id"] != float64(4) {
+		t.Fatalf("unexpected response: %v", response)
+	}
+}
+
+func TestCreateToken_Forbidden(t *testing.T) {
+	mockService := &MockTokenService{
+		CreateFunc: func(ctx context.Context, token *model.APIToken) (int, error) {
+			return 0, model.ErrForbidden
+		},
+	}
+
+	h := handler.NewTokenHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/tokens", bytes.NewReader([]byte(`{"name": "ci", "scopes": ["admin"]}`)))
+	res := httptest.NewRecorder()
+
+	h.CreateToken(res, req)
+
+	if res.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", res.Code)
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	var gotID int
+	mockService := &MockTokenService{
+		RevokeFunc: func(ctx context.Context, id int) error {
+			gotID = id
+			return nil
+		},
+	}
+
+	h := handler.NewTokenHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/tokens/7", nil)
+	req.SetPathValue("id", "7")
+	res := httptest.NewRecorder()
+
+	h.RevokeToken(res, req)
+
+	if res.Code != http.StatusNoContent || gotID != 7 {
+		t.Fatalf("expected token 7 revoked with 204, got %d for %d", res.Code, gotID)
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type WatcherHandler struct {
+	watcherService WatcherService
+}
+
+func NewWatcherHandler(watcherService WatcherService) *WatcherHandler {
+	return &WatcherHandler{watcherService: watcherService}
+}
+
+func (h *WatcherHandler) WatchIssue(w http.ResponseWriter, r *http.Request) {
+	issueID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid issue ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.watcherService.WatchIssue(r.Context(), issueID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WatcherHandler) UnwatchIssue(w http.ResponseWriter, r *http.Request) {
+	issueID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid issue ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.watcherService.UnwatchIssue(r.Context(), issueID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WatcherHandler) ListWatchedIssues(w http.ResponseWriter, r *http.Request) {
+	issues, err := h.watcherService.ListWatchedIssues(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(issues)
+}
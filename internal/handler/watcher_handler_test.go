@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MockWatcherService struct {
+	WatchFunc   func(ctx context.Context, issueID int) error
+	UnwatchFunc func(ctx context.Context, issueID int) error
+	ListFunc    func(ctx context.Context) ([]*model.Issue, error)
+}
+
+func (m *MockWatcherService) WatchIssue(ctx context.Context, issueID int) error {
+	return m.WatchFunc(ctx, issueID)
+}
+
+func (m *MockWatcherService) UnwatchIssue(ctx context.Context, issueID int) error {
+	return m.UnwatchFunc(ctx, issueID)
+}
+
+func (m *MockWatcherService) ListWatchedIssues(ctx context.Context) ([]*model.Issue, error) {
+	return m.ListFunc(ctx)
+}
+
+func TestWatchIssue(t *testing.T) {
+	mockService := &MockWatcherService{
+		WatchFunc: func(ctx context.Context, issueID int) error {
+			if _, ok := auth.UserFromContext(ctx); !ok {
+				return model.ErrUnauthorized
+			}
+			return nil
+		},
+	}
+
+	h := handler.NewWatcherHandler(mockService)
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user := r.Header.Get("X-Test-User"); user != "" {
+				r = r.WithContext(auth.WithUser(r.Context(), user))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Put("/issues/{id}/watchers/me", h.WatchIssue)
+
+	req := httptest.NewRequest(http.MethodPut, "/issues/1/watchers/me", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 without user, got %d", res.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/issues/1/watchers/me", nil)
+	req.Header.Set("X-Test-User", "alice")
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", res.Code)
+	}
+}
@@ -0,0 +1,89 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockWebhookService struct {
+	CreateFunc       func(ctx context.Context, webhook *model.Webhook) (int, error)
+	GetFunc          func(ctx context.Context, id int) (*model.Webhook, error)
+	UpdateFunc       func(ctx context.Context, webhook *model.Webhook) error
+	DeleteFunc       func(ctx context.Context, id int) error
+	ListFunc         func(ctx context.Context) ([]*model.Webhook, error)
+	ListDeliveryFunc func(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error)
+	ListAttemptsFunc func(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error)
+}
+
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, webhook *model.Webhook) (int, error) {
+	return m.CreateFunc(ctx, webhook)
+}
+
+func (m *MockWebhookService) GetWebhook(ctx context.Context, id int) (*model.Webhook, error) {
+	return m.GetFunc(ctx, id)
+}
+
+func (m *MockWebhookService) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	return m.UpdateFunc(ctx, webhook)
+}
+
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, id int) error {
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *MockWebhookService) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	return m.ListFunc(ctx)
+}
+
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error) {
+	return m.ListDeliveryFunc(ctx, webhookID)
+}
+
+func (m *MockWebhookService) ListDeliveryAttempts(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error) {
+	return m.ListAttemptsFunc(ctx, webhookID, deliveryID)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	var got model.Webhook
+	mockService := &MockWebhookService{
+		CreateFunc: func(ctx context.Context, webhook *model.Webhook) (int, error) {
+			got = *webhook
+			webhook.Secret = "generated"
+			return 2, nil
+		},
+	}
+
+	h := handler.NewWebhookHandler(mockService)
+
+	body := `{"url": "https://ci.example.com/hook", "events": ["issue.created"]}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(body))
+	res := httptest.NewRecorder()
+
+	h.CreateWebhook(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", res.Code)
+	}
+
+	if !got.Active {
+		t.Fatal("expected new webhook to be active by default")
+	}
+
+	var response struct {
+		ID     int    `json:"id"`
+		Secret string `json:"secret"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("cannot decode response: %v", err)
+	}
+
+	if response.ID != 2 || response.Secret != "generated" {
+		t.Fatalf("expected id and secret in response, got %+v", response)
+	}
+}
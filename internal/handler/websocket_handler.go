@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/presence"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+const (
+	// wsMaxMessageSize limits messages from clients, which only name channels.
+	wsMaxMessageSize = 4 << 10
+	// wsMaxChannels limits the channels a connection may subscribe to.
+	wsMaxChannels = 100
+	// wsWriteTimeout is how long a client may take to accept a message before it is disconnected.
+	wsWriteTimeout = 10 * time.Second
+
+	// projectField is the custom field project channels match, as there is no project entity.
+	projectField = "project"
+)
+
+// WebSocketHandler lets clients subscribe to channels of issue changes and see who else
+// is viewing an issue. Channels are named "issue:<id>" and "project:<name>".
+type WebSocketHandler struct {
+	events    EventStream
+	presence  PresenceTracker
+	heartbeat time.Duration
+}
+
+// NewWebSocketHandler returns a handler pinging clients every heartbeat and
+// disconnecting the ones that do not answer in time.
+func NewWebSocketHandler(events EventStream, presence PresenceTracker, heartbeat time.Duration) *WebSocketHandler {
+	return &WebSocketHandler{events: events, presence: presence, heartbeat: heartbeat}
+}
+
+// wsRequest is a message of the client: subscribe or unsubscribe to a channel.
+type wsRequest struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+}
+
+// wsReply confirms a request or reports why it was rejected.
+type wsReply struct {
+	Type    string `json:"type"` // subscribed, unsubscribed or error
+	Channel string `json:"channel,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// wsEvent delivers an issue change once, naming all subscribed channels it belongs to.
+type wsEvent struct {
+	Type     string           `json:"type"` // event
+	Channels []string         `json:"channels"`
+	ID       string           `json:"id"`
+	Event    model.IssueEvent `json:"event"`
+}
+
+// wsPresence lists the users viewing an issue.
+type wsPresence struct {
+	Type    string   `json:"type"` // presence
+	Channel string   `json:"channel"`
+	Viewers []string `json:"viewers"`
+}
+
+// wsChannel is a subscription of a connection.
+type wsChannel struct {
+	issueID int          // of issue channels
+	project streamFilter // of project channels
+}
+
+func (c wsChannel) matches(event model.IssueEvent) bool {
+	if c.issueID != 0 {
+		return event.IssueID == c.issueID
+	}
+	return c.project.matches(event)
+}
+
+func parseChannel(name string) (wsChannel, error) {
+	kind, value, _ := strings.Cut(name, ":")
+	switch kind {
+	case "issue":
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return wsChannel{}, fmt.Errorf("invalid issue ID %q", value)
+		}
+		return wsChannel{issueID: id}, nil
+	case "project":
+		if value == "" {
+			return wsChannel{}, fmt.Errorf("missing project name")
+		}
+		return wsChannel{project: streamFilter{customFields: map[string]string{projectField: value}}}, nil
+	}
+	return wsChannel{}, fmt.Errorf("unknown channel %q, expected issue:<id> or project:<name>", name)
+}
+
+// Connect upgrades the request to a WebSocket connection and serves it until either side closes it.
+// Subscribing to an issue channel counts as viewing the issue.
+func (h *WebSocketHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	// the connection outlives the timeouts of the server, heartbeats detect dead clients instead
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+
+	// subscribe before the upgrade, so no change made after the client connected is missed
+	sub, _ := h.events.Resume(r.Context(), "")
+	defer sub.Close()
+	session := h.presence.Join(r.Context())
+	defer session.Close()
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return // Accept has written the response
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(wsMaxMessageSize)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	logger := logging.FromContext(ctx)
+
+	requests := make(chan wsRequest)
+	go func() {
+		defer cancel()
+		for {
+			var req wsRequest
+			if err := wsjson.Read(ctx, conn, &req); err != nil {
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		defer cancel()
+		h.ping(ctx, conn)
+	}()
+
+	write := func(v any) bool {
+		ctx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+		defer cancel()
+		return wsjson.Write(ctx, conn, v) == nil
+	}
+
+	channels := make(map[string]wsChannel)
+	for {
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+
+		case req := <-requests:
+			ok = write(h.handleRequest(req, channels, session))
+
+		case event, open := <-sub.Events():
+			if !open {
+				if sub.Dropped() {
+					logger.Warn("WebSocket client fell behind")
+					conn.Close(websocket.StatusTryAgainLater, "fell behind, reconnect and reload")
+				} else {
+					conn.Close(websocket.StatusGoingAway, "server is shutting down")
+				}
+				return
+			}
+			var matched []string
+			for name, channel := range channels {
+				if channel.matches(event.IssueEvent) {
+					matched = append(matched, name)
+				}
+			}
+			ok = len(matched) == 0 || write(wsEvent{Type: "event", Channels: matched, ID: event.ID, Event: event.IssueEvent})
+
+		case <-session.Changed():
+			ok = true
+			for issueID, viewers := range session.Changes() {
+				if !write(wsPresence{Type: "presence", Channel: "issue:" + strconv.Itoa(issueID), Viewers: viewers}) {
+					ok = false
+					break
+				}
+			}
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// handleRequest applies a request of the client to its channels and returns the reply.
+func (h *WebSocketHandler) handleRequest(req wsRequest, channels map[string]wsChannel, session *presence.Session) wsReply {
+	switch req.Type {
+	case "subscribe":
+		channel, err := parseChannel(req.Channel)
+		if err != nil {
+			return wsReply{Type: "error", Channel: req.Channel, Message: err.Error()}
+		}
+		if _, ok := channels[req.Channel]; !ok && len(channels) >= wsMaxChannels {
+			return wsReply{Type: "error", Channel: req.Channel, Message: fmt.Sprintf("at most %d channels per connection", wsMaxChannels)}
+		}
+		channels[req.Channel] = channel
+		if channel.issueID != 0 {
+			session.View(channel.issueID)
+		}
+		return wsReply{Type: "subscribed", Channel: req.Channel}
+
+	case "unsubscribe":
+		channel, ok := channels[req.Channel]
+		if !ok {
+			return wsReply{Type: "error", Channel: req.Channel, Message: "not subscribed"}
+		}
+		delete(channels, req.Channel)
+		if channel.issueID != 0 {
+			session.Leave(channel.issueID)
+		}
+		return wsReply{Type: "unsubscribed", Channel: req.Channel}
+	}
+	return wsReply{Type: "error", Message: fmt.Sprintf("unknown message type %q, expected subscribe or unsubscribe", req.Type)}
+}
+
+// ping checks every heartbeat that the client still answers, and returns when it does not.
+func (h *WebSocketHandler) ping(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(ctx, h.heartbeat)
+			err := conn.Ping(ctx)
+			cancel()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/eventbus"
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/presence"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+type wsMessage struct {
+	Type     string
+	Channel  string
+	Channels []string
+	Message  string
+	ID       string
+	Event    model.IssueEvent
+	Viewers  []string
+}
+
+// newWebSocketServer serves the handler with the user named by the X-User header, like the auth middleware would.
+func newWebSocketServer(t *testing.T, bus *eventbus.Bus) *httptest.Server {
+	h := handler.NewWebSocketHandler(bus, presence.New(), time.Minute)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.Connect(w, r.WithContext(auth.WithUser(r.Context(), r.Header.Get("X-User"))))
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(bus.Close) // let the connections end before the server waits for them
+	return srv
+}
+
+type wsClient struct {
+	t    *testing.T
+	ctx  context.Context
+	conn *websocket.Conn
+}
+
+func dialWebSocket(t *testing.T, srv *httptest.Server, user string) *wsClient {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", &websocket.DialOptions{
+		HTTPHeader: http.Header{"X-User": {user}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return &wsClient{t: t, ctx: ctx, conn: conn}
+}
+
+func (c *wsClient) send(msgType, channel string) {
+	c.t.Helper()
+	if err := wsjson.Write(c.ctx, c.conn, map[string]string{"type": msgType, "channel": channel}); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *wsClient) next() wsMessage {
+	c.t.Helper()
+	var msg wsMessage
+	if err := wsjson.Read(c.ctx, c.conn, &msg); err != nil {
+		c.t.Fatal(err)
+	}
+	return msg
+}
+
+func (c *wsClient) expect(msgType, channel string) wsMessage {
+	c.t.Helper()
+	msg := c.next()
+	if msg.Type != msgType || msg.Channel != channel {
+		c.t.Fatalf("expected %s for %q, got %+v", msgType, channel, msg)
+	}
+	return msg
+}
+
+func TestWebSocket_EventsAndPresence(t *testing.T) {
+	bus := eventbus.New(8, 0)
+	srv := newWebSocketServer(t, bus)
+
+	alice := dialWebSocket(t, srv, "alice")
+	alice.send("subscribe", "issue:1")
+	alice.expect("subscribed", "issue:1")
+	if msg := alice.expect("presence", "issue:1"); !reflect.DeepEqual(msg.Viewers, []string{"alice"}) {
+		t.Fatalf("unexpected viewers %v", msg.Viewers)
+	}
+
+	bob := dialWebSocket(t, srv, "bob")
+	bob.send("subscribe", "issue:1")
+	bob.expect("subscribed", "issue:1")
+	bob.expect("presence", "issue:1")
+	if msg := alice.expect("presence", "issue:1"); !reflect.DeepEqual(msg.Viewers, []string{"alice", "bob"}) {
+		t.Fatalf("expected bob to join, got %v", msg.Viewers)
+	}
+
+	bus.Publish(context.Background(), model.IssueEvent{Type: model.EventIssueUpdated, IssueID: 2})
+	bus.Publish(context.Background(), model.IssueEvent{Type: model.EventIssueUpdated, IssueID: 1})
+	for _, client := range []*wsClient{alice, bob} {
+		msg := client.next()
+		if msg.Type != "event" || msg.Event.IssueID != 1 || msg.ID == "" || !reflect.DeepEqual(msg.Channels, []string{"issue:1"}) {
+			t.Fatalf("expected the event of issue 1, got %+v", msg)
+		}
+	}
+
+	bob.send("unsubscribe", "issue:1")
+	bob.expect("unsubscribed", "issue:1")
+	if msg := alice.expect("presence", "issue:1"); !reflect.DeepEqual(msg.Viewers, []string{"alice"}) {
+		t.Fatalf("expected bob to leave, got %v", msg.Viewers)
+	}
+}
+
+func TestWebSocket_ProjectChannel(t *testing.T) {
+	bus := eventbus.New(8, 0)
+	srv := newWebSocketServer(t, bus)
+
+	client := dialWebSocket(t, srv, "alice")
+	client.send("subscribe", "project:board")
+	client.expect("subscribed", "project:board")
+
+	bus.Publish(context.Background(), model.IssueEvent{
+		Type:    model.EventIssueCreated,
+		IssueID: 1,
+		Issue:   &model.Issue{ID: 1, CustomFields: map[string]any{"project": "other"}},
+	})
+	bus.Publish(context.Background(), model.IssueEvent{
+		Type:     model.EventIssueDeleted,
+		IssueID:  2,
+		Previous: &model.Issue{ID: 2, CustomFields: map[string]any{"project": "board"}},
+	})
+
+	if msg := client.next(); msg.Type != "event" || msg.Event.IssueID != 2 {
+		t.Fatalf("expected the event of issue 2, got %+v", msg)
+	}
+}
+
+func TestWebSocket_InvalidRequests(t *testing.T) {
+	srv := newWebSocketServer(t, eventbus.New(8, 0))
+	client := dialWebSocket(t, srv, "alice")
+
+	tests := []struct {
+		msgType, channel string
+	}{
+		{"subscribe", "board"},
+		{"subscribe", "issue:abc"},
+		{"subscribe", "project:"},
+		{"unsubscribe", "issue:1"},
+		{"watch", "issue:1"},
+	}
+	for _, tt := range tests {
+		client.send(tt.msgType, tt.channel)
+		if msg := client.next(); msg.Type != "error" || msg.Message == "" {
+			t.Fatalf("expected an error for %s %q, got %+v", tt.msgType, tt.channel, msg)
+		}
+	}
+
+	// the connection stays usable
+	client.send("subscribe", "issue:1")
+	client.expect("subscribed", "issue:1")
+}
+
+func TestWebSocket_ClosesOnShutdown(t *testing.T) {
+	bus := eventbus.New(8, 0)
+	srv := newWebSocketServer(t, bus)
+	client := dialWebSocket(t, srv, "alice")
+
+	bus.Close()
+
+	var msg wsMessage
+	if err := wsjson.Read(client.ctx, client.conn, &msg); websocket.CloseStatus(err) != websocket.StatusGoingAway {
+		t.Fatalf("expected the connection to close with going away, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package idempotency replays the stored response when a request is retried
+// with the same Idempotency-Key header.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+	maxBodySize  = 1 << 20
+)
+
+type Store interface {
+	ClaimIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) error
+	DeleteIdempotencyKey(ctx context.Context, scope, key string) error
+}
+
+// Middleware makes requests carrying an Idempotency-Key header safe to retry.
+// Keys are scoped to the tenant and user of the request and kept for ttl.
+//
+//   - the first request with a key is processed and its response stored;
+//   - a repeat with the same body gets the stored response, marked with Idempotent-Replayed;
+//   - a repeat with a different body is rejected with 422;
+//   - a repeat while the first request is still processed is rejected with 409.
+//
+// Responses that may change on a retry are not stored, so the request can be retried with the
+// same key: server errors, 409 and 429, and GraphQL responses reporting errors.
+// Requests without the header are passed through.
+func Middleware(store Store, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxKeyLength {
+				http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					http.Error(w, "request body too large for Idempotency-Key", http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, "cannot read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			user, _ := auth.UserFromContext(r.Context())
+			record := &model.IdempotencyRecord{
+				Scope:       tenant.ID(r.Context()) + "/" + user,
+				Key:         key,
+				RequestHash: requestHash(r, body),
+				ExpiresAt:   time.Now().Add(ttl),
+			}
+
+			existing, err := store.ClaimIdempotencyKey(r.Context(), record)
+			if err != nil {
+				logging.FromContext(r.Context()).Error("cannot claim idempotency key", "error", err)
+				http.Error(w, "cannot check Idempotency-Key", http.StatusInternalServerError)
+				return
+			}
+
+			if existing != nil {
+				replay(w, record, existing)
+				return
+			}
+
+			// the response is already sent, a cancelled request must not leave the key pending
+			ctx := context.WithoutCancel(r.Context())
+			release := func() {
+				if err := store.DeleteIdempotencyKey(ctx, record.Scope, record.Key); err != nil {
+					logging.FromContext(ctx).Error("cannot release idempotency key", "error", err)
+				}
+			}
+			// nor must a panicking handler, the retry would be rejected until the key expires
+			defer func() {
+				if v := recover(); v != nil {
+					release()
+					panic(v)
+				}
+			}()
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			if retryable(rec) {
+				release()
+				return
+			}
+
+			record.StatusCode = rec.status
+			record.ContentType = rec.Header().Get("Content-Type")
+			record.Body = rec.body.Bytes()
+			if err := store.CompleteIdempotencyKey(ctx, record); err != nil {
+				logging.FromContext(ctx).Error("cannot store response for idempotency key", "error", err)
+			}
+		})
+	}
+}
+
+func replay(w http.ResponseWriter, record, existing *model.IdempotencyRecord) {
+	switch {
+	case existing.RequestHash != record.RequestHash:
+		http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+	case existing.StatusCode == 0:
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "a request with this Idempotency-Key is still being processed", http.StatusConflict)
+	default:
+		if existing.ContentType != "" {
+			w.Header().Set("Content-Type", existing.ContentType)
+		}
+		w.Header().Set(ReplayedHeader, "true")
+		w.Header().Set("Content-Length", strconv.Itoa(len(existing.Body)))
+		w.WriteHeader(existing.StatusCode)
+		w.Write(existing.Body)
+	}
+}
+
+// retryable reports whether a retry of the request may get a different response.
+func retryable(rec *recorder) bool {
+	switch {
+	case rec.status >= http.StatusInternalServerError,
+		rec.status == http.StatusTooManyRequests,
+		rec.status == http.StatusConflict:
+		return true
+	case rec.status != http.StatusOK:
+		return false
+	}
+
+	// GraphQL reports failures, e.g. a rate limited mutation, with 200 and a list of errors
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		return false
+	}
+	var body struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	return json.Unmarshal(rec.body.Bytes(), &body) == nil && len(body.Errors) > 0
+}
+
+// requestHash identifies a request by method, path and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder passes the response through and keeps a copy of it.
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,190 @@
+package idempotency_test
+
+import (
+	"Go-IssueTracker-API/internal/idempotency"
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MemoryStore keeps idempotency records in memory.
+type MemoryStore struct {
+	records map[string]*model.IdempotencyRecord
+}
+
+func (m *MemoryStore) ClaimIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	if m.records == nil {
+		m.records = map[string]*model.IdempotencyRecord{}
+	}
+	if existing, ok := m.records[record.Scope+record.Key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	m.records[record.Scope+record.Key] = &copied
+	return nil, nil
+}
+
+func (m *MemoryStore) CompleteIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) error {
+	copied := *record
+	m.records[record.Scope+record.Key] = &copied
+	return nil
+}
+
+func (m *MemoryStore) DeleteIdempotencyKey(ctx context.Context, scope, key string) error {
+	delete(m.records, scope+key)
+	return nil
+}
+
+func newHandler(store idempotency.Store, calls *int, status int) http.Handler {
+	return idempotency.Middleware(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id":1}`))
+	}))
+}
+
+func post(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/issues", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	return res
+}
+
+func TestMiddleware_Replays(t *testing.T) {
+	var calls int
+	h := newHandler(&MemoryStore{}, &calls, http.StatusCreated)
+
+	first := post(h, "abc", `{"title":"Test"}`)
+	second := post(h, "abc", `{"title":"Test"}`)
+
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Fatalf("expected replayed 201 %q, got %d %q", first.Body.String(), second.Code, second.Body.String())
+	}
+	if second.Header().Get(idempotency.ReplayedHeader) != "true" || second.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected replay headers: %v", second.Header())
+	}
+}
+
+func TestMiddleware_DifferentBody(t *testing.T) {
+	var calls int
+	h := newHandler(&MemoryStore{}, &calls, http.StatusCreated)
+
+	post(h, "abc", `{"title":"Test"}`)
+	res := post(h, "abc", `{"title":"Other"}`)
+
+	if res.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", res.Code)
+	}
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+}
+
+func TestMiddleware_InProgress(t *testing.T) {
+	var retry *httptest.ResponseRecorder
+	var h http.Handler
+	h = idempotency.Middleware(&MemoryStore{}, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the client retries while the first request is still processed
+		retry = post(h, "abc", `{"title":"Test"}`)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	post(h, "abc", `{"title":"Test"}`)
+
+	if retry.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", retry.Code)
+	}
+}
+
+func TestMiddleware_ServerErrorsAreNotStored(t *testing.T) {
+	var calls int
+	h := newHandler(&MemoryStore{}, &calls, http.StatusInternalServerError)
+
+	post(h, "abc", `{"title":"Test"}`)
+	post(h, "abc", `{"title":"Test"}`)
+
+	if calls != 2 {
+		t.Fatalf("expected handler to run twice, ran %d times", calls)
+	}
+}
+
+func TestMiddleware_RetryableResponsesAreNotStored(t *testing.T) {
+	for _, status := range []int{http.StatusConflict, http.StatusTooManyRequests} {
+		var calls int
+		store := &MemoryStore{}
+		h := newHandler(store, &calls, status)
+
+		post(h, "abc", `{"title":"Test"}`)
+		post(h, "abc", `{"title":"Test"}`)
+
+		if calls != 2 {
+			t.Fatalf("%d: expected handler to run twice, ran %d times", status, calls)
+		}
+	}
+}
+
+func TestMiddleware_GraphQLErrorsAreNotStored(t *testing.T) {
+	var calls int
+	respond := `{"errors":[{"message":"rate limit exceeded"}],"data":null}`
+	h := idempotency.Middleware(&MemoryStore{}, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(respond))
+	}))
+
+	post(h, "abc", `{"query":"mutation { deleteIssue(id: 1) }"}`)
+	respond = `{"data":{"deleteIssue":true}}`
+	post(h, "abc", `{"query":"mutation { deleteIssue(id: 1) }"}`)
+	replayed := post(h, "abc", `{"query":"mutation { deleteIssue(id: 1) }"}`)
+
+	if calls != 2 {
+		t.Fatalf("expected handler to run until it succeeded, ran %d times", calls)
+	}
+	if replayed.Header().Get(idempotency.ReplayedHeader) != "true" || replayed.Body.String() != respond {
+		t.Fatalf("expected the successful response to be replayed, got %q", replayed.Body)
+	}
+}
+
+func TestMiddleware_PanicReleasesKey(t *testing.T) {
+	store := &MemoryStore{}
+	h := idempotency.Middleware(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Fatalf("expected the panic to propagate, got %v", v)
+			}
+		}()
+		post(h, "abc", `{"title":"Test"}`)
+	}()
+
+	if len(store.records) != 0 {
+		t.Fatalf("expected the key to be released, got %v", store.records)
+	}
+}
+
+func TestMiddleware_WithoutKey(t *testing.T) {
+	var calls int
+	h := newHandler(&MemoryStore{}, &calls, http.StatusCreated)
+
+	post(h, "", `{"title":"Test"}`)
+	post(h, "", `{"title":"Test"}`)
+
+	if calls != 2 {
+		t.Fatalf("expected handler to run twice, ran %d times", calls)
+	}
+}
@@ -0,0 +1,151 @@
+// Package logging carries a structured request logger in the context, so that
+// errors logged in services and repositories can be correlated with their request.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the ID of a request. An ID sent by the client or a proxy
+// is kept, otherwise one is generated; it is always echoed in the response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds request IDs taken from clients.
+const maxRequestIDLength = 128
+
+// New returns a JSON logger writing records of the given level ("debug", "info", "warn" or "error") and above.
+func New(w io.Writer, level string) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("unknown log level %q", level)
+	}
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: l})), nil
+}
+
+type loggerKey struct{}
+
+type requestKey struct{}
+
+type requestIDKey struct{}
+
+// request collects the attributes added to a request's logger further down the
+// middleware chain, so that they also appear in its access log record.
+type request struct {
+	mu    sync.Mutex
+	attrs []any
+}
+
+// FromContext returns the logger of ctx, or slog.Default() when none is set.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// With returns a copy of ctx whose logger adds args to every record,
+// including the access log record of the request ctx belongs to.
+func With(ctx context.Context, args ...any) context.Context {
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		req.mu.Lock()
+		req.attrs = append(req.attrs, args...)
+		req.mu.Unlock()
+	}
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+// RequestID returns the ID of the request ctx belongs to, if any.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware assigns every request an ID, stores a logger annotated with it (and the
+// trace ID, when tracing runs first) in the request context and writes one access log
+// record per request with method, route, status, latency and the attributes added by With.
+// It must be installed on the router itself to see the route pattern.
+func Middleware(base *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			logger := base.With("request_id", id)
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				logger = logger.With("trace_id", sc.TraceID().String())
+			}
+
+			req := &request{}
+			ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+			ctx = context.WithValue(ctx, requestKey{}, req)
+			ctx = WithLogger(ctx, logger)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+
+			req.mu.Lock()
+			attrs := append([]any{
+				"method", r.Method,
+				"route", route,
+				"path", r.URL.Path,
+				"status", status,
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+				"bytes", ww.BytesWritten(),
+			}, req.attrs...)
+			req.mu.Unlock()
+
+			logger.Log(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	// IDs end up in log records and response headers, only printable ASCII is accepted
+	return !strings.ContainsFunc(id, func(c rune) bool { return c < 0x21 || c > 0x7e })
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
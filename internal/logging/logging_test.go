@@ -0,0 +1,118 @@
+package logging_test
+
+import (
+	"Go-IssueTracker-API/internal/logging"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line is not JSON: %q", line)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+func TestMiddleware_LogsRequest(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(logging.Middleware(logger))
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(logging.With(r.Context(), "user", "alice")))
+		})
+	})
+	r.Get("/issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Error("statement failed")
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/issues/7", nil))
+
+	id := res.Header().Get(logging.RequestIDHeader)
+	if len(id) != 32 {
+		t.Fatalf("expected a generated request ID, got %q", id)
+	}
+
+	recs := records(t, &buf)
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 log records, got %d", len(recs))
+	}
+	inner, access := recs[0], recs[1]
+	if inner["request_id"] != id || inner["user"] != "alice" {
+		t.Fatalf("handler record not correlated with the request: %v", inner)
+	}
+	if access["msg"] != "request" || access["level"] != "ERROR" || access["request_id"] != id ||
+		access["method"] != "GET" || access["route"] != "/issues/{id}" || access["status"] != float64(500) ||
+		access["user"] != "alice" {
+		t.Fatalf("unexpected access record: %v", access)
+	}
+	if _, ok := access["latency_ms"].(float64); !ok {
+		t.Fatalf("expected latency in access record: %v", access)
+	}
+}
+
+func TestMiddleware_RequestIDFromHeader(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"valid", "req-123", true},
+		{"control characters", "req\x01", false},
+		{"too long", strings.Repeat("a", 129), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			h := logging.Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = logging.RequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(logging.RequestIDHeader, tt.header)
+			res := httptest.NewRecorder()
+			h.ServeHTTP(res, req)
+
+			if got := res.Header().Get(logging.RequestIDHeader); (got == tt.header) != tt.keep || got != seen {
+				t.Fatalf("header %q: got response ID %q, context ID %q", tt.header, got, seen)
+			}
+		})
+	}
+}
+
+func TestFromContext_Default(t *testing.T) {
+	if logging.FromContext(context.Background()) != slog.Default() {
+		t.Fatal("expected the default logger outside of requests")
+	}
+}
+
+func TestNew_UnknownLevel(t *testing.T) {
+	if _, err := logging.New(&bytes.Buffer{}, "verbose"); err == nil {
+		t.Fatal("expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,160 @@
+package mail_test
+
+import (
+	"Go-IssueTracker-API/internal/mail"
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// smtpSink is a minimal SMTP server that accepts every message, like the mailpit service from docker-compose.yml.
+type smtpSink struct {
+	listener net.Listener
+	messages chan sinkMessage
+}
+
+type sinkMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	sink := &smtpSink{listener: listener, messages: make(chan sinkMessage, 10)}
+	go sink.serve()
+	return sink
+}
+
+func (s *smtpSink) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 sink ready")
+
+	var msg sinkMessage
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			tp.PrintfLine("250 sink")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			msg.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			tp.PrintfLine("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			tp.PrintfLine("250 ok")
+		case cmd == "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.messages <- msg
+			msg = sinkMessage{}
+			tp.PrintfLine("250 queued")
+		case cmd == "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	sink := newSMTPSink(t)
+
+	sender, err := mail.NewSMTPSender(mail.SMTPConfig{
+		Host: "127.0.0.1",
+		Port: sink.port(),
+		From: "Issue Tracker <tracker@example.com>",
+	})
+	if err != nil {
+		t.Fatalf("cannot create sender: %v", err)
+	}
+
+	msg := &mail.Message{
+		To:      []string{"alice@example.com"},
+		Subject: "[Issue #1] Привет was updated",
+		Text:    "plain body",
+		HTML:    "<p>html body</p>",
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got := <-sink.messages
+	if got.from != "tracker@example.com" {
+		t.Fatalf("unexpected sender %q", got.from)
+	}
+	if len(got.to) != 1 || got.to[0] != "alice@example.com" {
+		t.Fatalf("unexpected recipients %v", got.to)
+	}
+
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(got.data)))
+	header, err := r.ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("cannot read header: %v", err)
+	}
+
+	if !strings.HasPrefix(header.Get("Content-Type"), "multipart/alternative") {
+		t.Fatalf("expected multipart/alternative, got %q", header.Get("Content-Type"))
+	}
+	if !strings.HasPrefix(header.Get("Subject"), "=?utf-8?q?") {
+		t.Fatalf("expected encoded subject, got %q", header.Get("Subject"))
+	}
+	for _, body := range []string{"plain body", "<p>html body</p>"} {
+		if !strings.Contains(got.data, body) {
+			t.Fatalf("expected message to contain %q:\n%s", body, got.data)
+		}
+	}
+}
+
+func TestSMTPSender_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	sender, err := mail.NewSMTPSender(mail.SMTPConfig{Host: "127.0.0.1", Port: port, From: "tracker@example.com"})
+	if err != nil {
+		t.Fatalf("cannot create sender: %v", err)
+	}
+
+	err = sender.Send(context.Background(), &mail.Message{To: []string{"alice@example.com"}})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,120 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"Go-IssueTracker-API/internal/model"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Change is a field of an issue that differs between two versions.
+type Change struct {
+	Field string
+	From  string
+	To    string
+}
+
+var templateFuncs = map[string]any{
+	"title":   eventTitle,
+	"action":  eventAction,
+	"changes": eventChanges,
+}
+
+// Renderer builds notification emails from the embedded templates.
+type Renderer struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	text, err := texttemplate.New("").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.txt.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := htmltemplate.New("").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Renderer{text: text, html: html}, nil
+}
+
+// Notification renders the email sent for a single event.
+func (r *Renderer) Notification(to string, event model.IssueEvent) (*Message, error) {
+	subject := fmt.Sprintf("[Issue #%d] %s was %s", event.IssueID, eventTitle(event), eventAction(event))
+	data := map[string]any{"Event": event}
+
+	return r.render(to, subject, "notification", data)
+}
+
+// Digest renders the email summarizing several events.
+func (r *Renderer) Digest(to string, events []model.IssueEvent) (*Message, error) {
+	subject := fmt.Sprintf("[Issues] %d change(s) on issues you are subscribed to", len(events))
+	data := map[string]any{"Events": events}
+
+	return r.render(to, subject, "digest", data)
+}
+
+func (r *Renderer) render(to, subject, name string, data any) (*Message, error) {
+	var textBody bytes.Buffer
+	if err := r.text.ExecuteTemplate(&textBody, name+".txt.tmpl", data); err != nil {
+		return nil, err
+	}
+
+	var htmlBody bytes.Buffer
+	if err := r.html.ExecuteTemplate(&htmlBody, name+".html.tmpl", data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:      []string{to},
+		Subject: subject,
+		Text:    textBody.String(),
+		HTML:    htmlBody.String(),
+	}, nil
+}
+
+func eventTitle(event model.IssueEvent) string {
+	if event.Issue != nil {
+		return event.Issue.Title
+	}
+	if event.Previous != nil {
+		return event.Previous.Title
+	}
+	return ""
+}
+
+func eventAction(event model.IssueEvent) string {
+	action, _ := strings.CutPrefix(event.Type, "issue.")
+	return strings.ReplaceAll(action, "_", " ")
+}
+
+func eventChanges(event model.IssueEvent) []Change {
+	if event.Issue == nil || event.Previous == nil {
+		return nil
+	}
+
+	before, after := event.Previous, event.Issue
+	fields := []Change{
+		{"Title", before.Title, after.Title},
+		{"Description", before.Description, after.Description},
+		{"Status", before.Status, after.Status},
+		{"Assignee", before.Assignee, after.Assignee},
+	}
+
+	var changes []Change
+	for _, c := range fields {
+		if c.From != c.To {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,184 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/webhook"
+)
+
+type Store interface {
+	ClaimPendingNotifications(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]*model.EmailNotification, error)
+	ClaimDueDigestNotifications(ctx context.Context, before time.Time, limit, maxAttempts int, lease time.Duration) ([]*model.EmailNotification, error)
+	MarkNotificationsSent(ctx context.Context, ids []int) error
+	RecordNotificationFailure(ctx context.Context, ids []int, reason string, nextAttemptAt time.Time) error
+}
+
+type WorkerConfig struct {
+	PollInterval   time.Duration // how often the queue is checked
+	DigestInterval time.Duration // how long digest notifications are collected before they are sent
+	BatchSize      int           // immediate notifications, and digests, sent per poll
+	MaxAttempts    int           // attempts before a notification is given up
+	BaseBackoff    time.Duration // delay before the first retry, doubled for every next one
+	MaxBackoff     time.Duration
+	SendTimeout    time.Duration // timeout of a single email
+}
+
+// Worker sends queued notifications: one email per change to regular subscribers
+// and one email per digest interval to digest subscribers. Notifications are claimed
+// before they are sent, so several workers can share the database.
+type Worker struct {
+	store    Store
+	sender   Sender
+	renderer *Renderer
+	cfg      WorkerConfig
+}
+
+func NewWorker(store Store, sender Sender, renderer *Renderer, cfg WorkerConfig) *Worker {
+	return &Worker{store: store, sender: sender, renderer: renderer, cfg: cfg}
+}
+
+// Run processes the queue until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.ProcessDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logging.FromContext(ctx).Error("mail worker failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue sends pending notifications and the digests that are due at the given time.
+// A notification that cannot be rendered or sent is recorded as failed and the others are
+// still processed; the errors of the store are returned together.
+func (w *Worker) ProcessDue(ctx context.Context, now time.Time) error {
+	var errs []error
+
+	// a claimed notification is retried by another worker if this one dies before recording it;
+	// the lease covers sending the whole batch one after another
+	lease := time.Duration(w.cfg.BatchSize)*w.cfg.SendTimeout + w.cfg.PollInterval
+	deadline := time.Now().Add(lease)
+	pending, err := w.store.ClaimPendingNotifications(ctx, w.cfg.BatchSize, w.cfg.MaxAttempts, lease)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for i, n := range pending {
+		// what could not be sent before the lease ends is left to the next claim rather than sent twice
+		if time.Until(deadline) < w.cfg.SendTimeout {
+			break
+		}
+		if err := w.process(ctx, pending[i:i+1], func() (*Message, error) {
+			event, err := decodeEvent(n)
+			if err != nil {
+				return nil, err
+			}
+			return w.renderer.Notification(n.Email, event)
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	deadline = time.Now().Add(lease)
+	digests, err := w.store.ClaimDueDigestNotifications(ctx, now.Add(-w.cfg.DigestInterval), w.cfg.BatchSize, w.cfg.MaxAttempts, lease)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// notifications come ordered by email, so each run of equal emails forms one digest
+	for start := 0; start < len(digests); {
+		end := start
+		for end < len(digests) && digests[end].Email == digests[start].Email {
+			end++
+		}
+		if time.Until(deadline) < w.cfg.SendTimeout {
+			break
+		}
+
+		batch := digests[start:end]
+		if err := w.process(ctx, batch, func() (*Message, error) {
+			var events []model.IssueEvent
+			for _, n := range batch {
+				event, err := decodeEvent(n)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
+			return w.renderer.Digest(batch[0].Email, events)
+		}); err != nil {
+			errs = append(errs, err)
+		}
+
+		start = end
+	}
+
+	return errors.Join(errs...)
+}
+
+// process renders and sends the message covering the notifications. A message that cannot
+// be rendered is recorded as a failed attempt like an email that cannot be sent, so it does not
+// hold up the queue.
+func (w *Worker) process(ctx context.Context, notifications []*model.EmailNotification, render func() (*Message, error)) error {
+	msg, err := render()
+	if err != nil {
+		return w.fail(ctx, notifications, "cannot render email", err)
+	}
+	return w.send(ctx, msg, notifications)
+}
+
+// send delivers the message and marks the notifications it covers as sent, or records the failure.
+// Only errors of the store are returned.
+func (w *Worker) send(ctx context.Context, msg *Message, notifications []*model.EmailNotification) error {
+	sendCtx, cancel := context.WithTimeout(ctx, w.cfg.SendTimeout)
+	defer cancel()
+
+	if err := w.sender.Send(sendCtx, msg); err != nil {
+		return w.fail(ctx, notifications, "cannot send email", err)
+	}
+
+	ids := make([]int, len(notifications))
+	for i, n := range notifications {
+		ids[i] = n.ID
+	}
+	return w.store.MarkNotificationsSent(ctx, ids)
+}
+
+// fail records a failed attempt of the notifications, which are retried with exponential
+// backoff until they reach MaxAttempts.
+func (w *Worker) fail(ctx context.Context, notifications []*model.EmailNotification, msg string, err error) error {
+	ids := make([]int, len(notifications))
+	attempts := 0
+	for i, n := range notifications {
+		ids[i] = n.ID
+		attempts = max(attempts, n.Attempts+1)
+	}
+
+	logger := logging.FromContext(ctx)
+	to := notifications[0].Email
+	if attempts >= w.cfg.MaxAttempts {
+		logger.Error(msg+", giving up", "to", to, "attempts", attempts, "error", err)
+	} else {
+		logger.Warn(msg, "to", to, "attempts", attempts, "error", err)
+	}
+	next := time.Now().Add(webhook.Backoff(attempts, w.cfg.BaseBackoff, w.cfg.MaxBackoff))
+	return w.store.RecordNotificationFailure(ctx, ids, err.Error(), next)
+}
+
+func decodeEvent(n *model.EmailNotification) (model.IssueEvent, error) {
+	var event model.IssueEvent
+	err := json.Unmarshal(n.Payload, &event)
+	return event, err
+}
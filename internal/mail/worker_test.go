@@ -0,0 +1,223 @@
+package mail_test
+
+import (
+	"Go-IssueTracker-API/internal/mail"
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockStore struct {
+	pending []*model.EmailNotification
+	digests []*model.EmailNotification
+	before  time.Time
+	sent    []int
+	failed  []int
+	next    time.Time     // next attempt of the last failure
+	lease   time.Duration // of the last claim
+}
+
+func (m *MockStore) ClaimPendingNotifications(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]*model.EmailNotification, error) {
+	m.lease = lease
+	return m.pending, nil
+}
+
+func (m *MockStore) ClaimDueDigestNotifications(ctx context.Context, before time.Time, limit, maxAttempts int, lease time.Duration) ([]*model.EmailNotification, error) {
+	m.before = before
+	m.lease = lease
+	return m.digests, nil
+}
+
+func (m *MockStore) MarkNotificationsSent(ctx context.Context, ids []int) error {
+	m.sent = append(m.sent, ids...)
+	return nil
+}
+
+func (m *MockStore) RecordNotificationFailure(ctx context.Context, ids []int, reason string, nextAttemptAt time.Time) error {
+	m.failed = append(m.failed, ids...)
+	m.next = nextAttemptAt
+	return nil
+}
+
+type MockSender struct {
+	messages []*mail.Message
+	err      error
+}
+
+func (m *MockSender) Send(ctx context.Context, msg *mail.Message) error {
+	m.messages = append(m.messages, msg)
+	return m.err
+}
+
+func notification(t *testing.T, id int, email string, digest bool, event model.IssueEvent) *model.EmailNotification {
+	t.Helper()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("cannot marshal event: %v", err)
+	}
+	return &model.EmailNotification{ID: id, Email: email, IssueID: event.IssueID, Event: event.Type, Payload: payload, Digest: digest}
+}
+
+func newWorker(t *testing.T, store mail.Store, sender mail.Sender) *mail.Worker {
+	t.Helper()
+
+	renderer, err := mail.NewRenderer()
+	if err != nil {
+		t.Fatalf("cannot create renderer: %v", err)
+	}
+
+	return mail.NewWorker(store, sender, renderer, mail.WorkerConfig{
+		PollInterval:   time.Second,
+		DigestInterval: time.Hour,
+		BatchSize:      10,
+		MaxAttempts:    3,
+		BaseBackoff:    time.Minute,
+		MaxBackoff:     time.Hour,
+		SendTimeout:    time.Second,
+	})
+}
+
+func TestWorker_ImmediateNotification(t *testing.T) {
+	event := model.IssueEvent{
+		Type:     model.EventIssueUpdated,
+		IssueID:  7,
+		Issue:    &model.Issue{ID: 7, Title: "Login fails", Status: "done"},
+		Previous: &model.Issue{ID: 7, Title: "Login fails", Status: "open"},
+		Actor:    "bob",
+	}
+	store := &MockStore{pending: []*model.EmailNotification{notification(t, 1, "alice@example.com", false, event)}}
+	sender := &MockSender{}
+
+	if err := newWorker(t, store, sender).ProcessDue(context.Background(), time.Now()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(sender.messages) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(sender.messages))
+	}
+
+	msg := sender.messages[0]
+	if msg.Subject != "[Issue #7] Login fails was updated" {
+		t.Fatalf("unexpected subject %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Text, "Status: open -> done") || !strings.Contains(msg.Text, "by bob") {
+		t.Fatalf("expected change in text body, got:\n%s", msg.Text)
+	}
+	if !strings.Contains(msg.HTML, "<del>open</del>") {
+		t.Fatalf("expected change in html body, got:\n%s", msg.HTML)
+	}
+
+	if !slices.Equal(store.sent, []int{1}) {
+		t.Fatalf("expected notification to be marked sent, got %v", store.sent)
+	}
+}
+
+func TestWorker_Digest(t *testing.T) {
+	created := model.IssueEvent{Type: model.EventIssueCreated, IssueID: 1, Issue: &model.Issue{ID: 1, Title: "<script>"}}
+	deleted := model.IssueEvent{Type: model.EventIssueDeleted, IssueID: 2, Previous: &model.Issue{ID: 2, Title: "Old"}}
+
+	store := &MockStore{digests: []*model.EmailNotification{
+		notification(t, 1, "alice@example.com", true, created),
+		notification(t, 2, "alice@example.com", true, deleted),
+		notification(t, 3, "bob@example.com", true, deleted),
+	}}
+	sender := &MockSender{}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := newWorker(t, store, sender).ProcessDue(context.Background(), now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !store.before.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("expected digests older than an hour, got %v", store.before)
+	}
+
+	if len(sender.messages) != 2 {
+		t.Fatalf("expected one digest per subscriber, got %d", len(sender.messages))
+	}
+
+	alice := sender.messages[0]
+	if alice.To[0] != "alice@example.com" || !strings.Contains(alice.Text, "2 change(s)") {
+		t.Fatalf("unexpected digest for alice:\n%s", alice.Text)
+	}
+	if strings.Contains(alice.HTML, "<script>") {
+		t.Fatal("expected html body to be escaped")
+	}
+
+	if !slices.Equal(store.sent, []int{1, 2, 3}) {
+		t.Fatalf("expected all notifications to be marked sent, got %v", store.sent)
+	}
+}
+
+func TestWorker_SendFailure(t *testing.T) {
+	event := model.IssueEvent{Type: model.EventIssueCreated, IssueID: 1, Issue: &model.Issue{ID: 1, Title: "Test"}}
+	store := &MockStore{pending: []*model.EmailNotification{notification(t, 1, "alice@example.com", false, event)}}
+	sender := &MockSender{err: errors.New("connection refused")}
+
+	if err := newWorker(t, store, sender).ProcessDue(context.Background(), time.Now()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(store.sent) != 0 || !slices.Equal(store.failed, []int{1}) {
+		t.Fatalf("expected failure to be recorded, sent %v failed %v", store.sent, store.failed)
+	}
+	if delay := time.Until(store.next); delay < 59*time.Second || delay > time.Minute {
+		t.Fatalf("expected a retry after 1m, got %v", delay)
+	}
+}
+
+func TestWorker_DigestFailureBacksOff(t *testing.T) {
+	event := model.IssueEvent{Type: model.EventIssueUpdated, IssueID: 1, Issue: &model.Issue{ID: 1, Title: "Test"}}
+	first := notification(t, 1, "alice@example.com", true, event)
+	first.Attempts = 2
+	second := notification(t, 2, "alice@example.com", true, event)
+	store := &MockStore{digests: []*model.EmailNotification{first, second}}
+	sender := &MockSender{err: errors.New("connection refused")}
+
+	if err := newWorker(t, store, sender).ProcessDue(context.Background(), time.Now()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// the digest is retried as often as its most tried notification
+	if !slices.Equal(store.failed, []int{1, 2}) {
+		t.Fatalf("expected both notifications to fail, got %v", store.failed)
+	}
+	if delay := time.Until(store.next); delay < 3*time.Minute || delay > 4*time.Minute {
+		t.Fatalf("expected a retry after 4m, got %v", delay)
+	}
+}
+
+func TestWorker_BadPayloadDoesNotStopBatch(t *testing.T) {
+	event := model.IssueEvent{Type: model.EventIssueCreated, IssueID: 1, Issue: &model.Issue{ID: 1, Title: "Test"}}
+	broken := notification(t, 1, "alice@example.com", false, event)
+	broken.Payload = []byte("{")
+	store := &MockStore{pending: []*model.EmailNotification{broken, notification(t, 2, "bob@example.com", false, event)}}
+	sender := &MockSender{}
+
+	if err := newWorker(t, store, sender).ProcessDue(context.Background(), time.Now()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !slices.Equal(store.failed, []int{1}) || !slices.Equal(store.sent, []int{2}) {
+		t.Fatalf("expected only the broken notification to fail, sent %v failed %v", store.sent, store.failed)
+	}
+}
+
+func TestWorker_LeaseCoversBatch(t *testing.T) {
+	store := &MockStore{}
+
+	if err := newWorker(t, store, &MockSender{}).ProcessDue(context.Background(), time.Now()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// 10 emails of up to 1s each must not be claimed by another worker meanwhile
+	if store.lease < 10*time.Second {
+		t.Fatalf("expected a lease of at least 10s, got %v", store.lease)
+	}
+}
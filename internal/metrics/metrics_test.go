@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"Go-IssueTracker-API/internal/metrics"
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	res := httptest.NewRecorder()
+	m.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(res.Body)
+	return string(body)
+}
+
+func TestMiddleware_LabelsRoutePattern(t *testing.T) {
+	m := metrics.New()
+
+	r := chi.NewRouter()
+	r.Use(m.Middleware)
+	r.Get("/issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, path := range []string{"/issues/1", "/issues/2", "/nope"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	out := scrape(t, m)
+	for _, want := range []string{
+		`issuetracker_http_requests_total{method="GET",route="/issues/{id}",status="404"} 2`,
+		`issuetracker_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`issuetracker_http_request_duration_seconds_count{method="GET",route="/issues/{id}"} 2`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in metrics output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	m := metrics.New()
+	ctx := context.Background()
+
+	m.Publish(ctx, model.IssueEvent{Type: model.EventIssueCreated, Issue: &model.Issue{Status: "open"}})
+	m.Publish(ctx, model.IssueEvent{
+		Type:     model.EventIssueStatusChanged,
+		Issue:    &model.Issue{Status: "done"},
+		Previous: &model.Issue{Status: "open"},
+	})
+
+	out := scrape(t, m)
+	for _, want := range []string{
+		`issuetracker_issue_events_total{event="issue.created"} 1`,
+		`issuetracker_issue_status_transitions_total{from="open",to="done"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in metrics output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Attachment is the metadata of a file attached to an issue.
+// The file itself lives in blob storage under StorageKey.
+type Attachment struct {
+	ID          int       `json:"id"`
+	IssueID     int       `json:"issue_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+const (
+	EventIssueCreated       = "issue.created"
+	EventIssueUpdated       = "issue.updated"
+	EventIssueDeleted       = "issue.deleted"
+	EventIssueStatusChanged = "issue.status_changed"
+)
+
+// EventTypes lists all event types published by IssueService.
+var EventTypes = []string{
+	EventIssueCreated,
+	EventIssueUpdated,
+	EventIssueDeleted,
+	EventIssueStatusChanged,
+}
+
+// IssueEvent describes a change of an issue.
+// Previous holds the state before an update or delete, Issue the state after create or update.
+type IssueEvent struct {
+	Type       string    `json:"type"`
+	IssueID    int       `json:"issue_id"`
+	Issue      *Issue    `json:"issue,omitempty"`
+	Previous   *Issue    `json:"previous,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+const (
+	FieldTypeString = "string"
+	FieldTypeNumber = "number"
+	FieldTypeEnum   = "enum"
+	FieldTypeDate   = "date"
+	FieldTypeUser   = "user"
+)
+
+// DateLayout is the format expected for values of date fields.
+const DateLayout = "2006-01-02"
+
+// FieldDefinition describes a custom field that can be set on issues.
+// Options lists the allowed values of an enum field.
+type FieldDefinition struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required"`
+}
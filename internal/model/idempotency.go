@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// IdempotencyRecord remembers the response to a request sent with an Idempotency-Key header.
+// A record without StatusCode belongs to a request that is still being processed.
+type IdempotencyRecord struct {
+	Scope       string // tenant and user the key belongs to
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Roles ordered from least to most privileged, see service.MemberService for what each one may do.
+const (
+	RoleViewer     = "viewer"
+	RoleReporter   = "reporter"
+	RoleDeveloper  = "developer"
+	RoleMaintainer = "maintainer"
+	RoleAdmin      = "admin"
+)
+
+var Roles = []string{RoleViewer, RoleReporter, RoleDeveloper, RoleMaintainer, RoleAdmin}
+
+// Permissions checked before changing issues, memberships and the settings of a tenant.
+const (
+	PermissionCreateIssue    = "issue.create"
+	PermissionEditOwnIssue   = "issue.edit_own" // issues the caller reported
+	PermissionEditIssue      = "issue.edit"
+	PermissionCloseIssue     = "issue.close"
+	PermissionDeleteIssue    = "issue.delete"
+	PermissionManageMembers  = "members.manage"
+	PermissionManageFields   = "fields.manage"
+	PermissionManageWebhooks = "webhooks.manage"
+)
+
+// Member assigns a role to a user.
+type Member struct {
+	User      string    `json:"user"`
+	Role      string    `json:"role"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -1,8 +1,28 @@
 package model
 
+// IssueStatuses lists the statuses an issue can have.
+var IssueStatuses = []string{"open", "in_progress", "done"}
+
 type Issue struct {
-	ID          int `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-}
\ No newline at end of file
+	ID           int            `json:"id"`
+	Title        string         `json:"title"`
+	Description  string         `json:"description"`
+	Status       string         `json:"status"`
+	Reporter     string         `json:"reporter"`
+	Assignee     string         `json:"assignee"`
+	UpdatedBy    string         `json:"updated_by"`
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+}
+
+// IssueFilter narrows down the result of ListIssues. Empty fields match every issue,
+// CustomFields matches issues whose custom field values are equal to the given ones.
+type IssueFilter struct {
+	Status       string
+	Assignee     string
+	Reporter     string
+	CustomFields map[string]any
+
+	// AfterID and Limit page through the issues ordered by ID, CountIssues ignores them.
+	AfterID int // only issues with a greater ID
+	Limit   int // at most that many issues, 0 for all
+}
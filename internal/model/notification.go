@@ -0,0 +1,29 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EmailSubscription subscribes an email address to changes of an issue.
+// Digest subscribers get one email per digest interval instead of one per change.
+type EmailSubscription struct {
+	ID        int       `json:"id"`
+	IssueID   int       `json:"issue_id"`
+	Email     string    `json:"email"`
+	Digest    bool      `json:"digest"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EmailNotification is an issue event queued for sending to a subscriber.
+// Payload holds the JSON encoded IssueEvent.
+type EmailNotification struct {
+	ID        int             `json:"id"`
+	Email     string          `json:"email"`
+	IssueID   int             `json:"issue_id"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload"`
+	Digest    bool            `json:"digest"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt time.Time       `json:"created_at"`
+}
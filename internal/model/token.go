@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+const (
+	ScopeIssuesRead  = "issues:read"
+	ScopeIssuesWrite = "issues:write"
+	ScopeAdmin       = "admin" // grants every other scope
+)
+
+var Scopes = []string{ScopeIssuesRead, ScopeIssuesWrite, ScopeAdmin}
+
+// APITokenPrefix starts every API token, it tells them apart from JWTs.
+const APITokenPrefix = "itk_"
+
+// APIToken is a personal access token for non-interactive clients.
+// Only the hash of the token is stored, Token is set once when the token is created.
+// A nil ExpiresAt means the token does not expire.
+type APIToken struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	User       string     `json:"user"`
+	Tenant     string     `json:"tenant"`
+	Scopes     []string   `json:"scopes"`
+	Token      string     `json:"token,omitempty"`
+	Hash       string     `json:"-"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
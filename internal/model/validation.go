@@ -0,0 +1,51 @@
+package model
+
+import "strings"
+
+// Codes of field errors, stable for clients to act on.
+const (
+	CodeRequired          = "required"
+	CodeTooLong           = "too_long"
+	CodeInvalidCharacters = "invalid_characters"
+	CodeInvalidValue      = "invalid_value"
+	CodeInvalidType       = "invalid_type"
+	CodeUnknownField      = "unknown_field"
+)
+
+// FieldError describes why the value of one request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError collects all field errors of a request, so clients can show them at once.
+// It matches ErrInvalidInput with errors.Is.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Add records that field was rejected.
+func (e *ValidationError) Add(field, code, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns e if any field was rejected, nil otherwise.
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Field + ": " + fe.Message
+	}
+	return ErrInvalidInput.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidInput
+}
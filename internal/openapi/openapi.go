@@ -0,0 +1,75 @@
+// Package openapi serves the OpenAPI 3.1 description of the API and a Swagger UI for it.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sync"
+
+	swaggerFiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// The document is maintained as YAML, which is easier to edit and review, and served as JSON.
+//
+//go:embed openapi.yaml
+var specYAML []byte
+
+//go:embed docs.html
+var docsHTML []byte
+
+var spec = sync.OnceValues(func() ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(specYAML, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+})
+
+// Spec returns the OpenAPI document as JSON.
+func Spec() ([]byte, error) {
+	return spec()
+}
+
+// Handler serves the document at /openapi.json.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	body, err := Spec()
+	if err != nil {
+		http.Error(w, "cannot render OpenAPI document", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// Docs serves a Swagger UI page at /docs that renders /openapi.json.
+// The page loads the Swagger UI scripts from Assets, not from a CDN.
+func Docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(docsHTML)
+}
+
+// docsAssets are the files of the Swagger UI distribution the page needs.
+var docsAssets = map[string]bool{
+	"swagger-ui.css":       true,
+	"swagger-ui-bundle.js": true,
+}
+
+var assets = http.FileServerFS(swaggerFiles.FS)
+
+// Assets serves the Swagger UI scripts and styles at /docs/{file}. They are embedded
+// into the binary with the version pinned in go.mod.
+func Assets(w http.ResponseWriter, r *http.Request) {
+	file := path.Base(r.URL.Path)
+	if !docsAssets[file] {
+		http.NotFound(w, r)
+		return
+	}
+
+	r2 := r.Clone(r.Context())
+	r2.URL.Path = "/" + file
+	assets.ServeHTTP(w, r2)
+}
@@ -0,0 +1,151 @@
+// Package presence tracks who is viewing which issue, so clients collaborating
+// over WebSocket can show each other.
+package presence
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"slices"
+	"sync"
+)
+
+type issueKey struct {
+	tenant  string
+	issueID int
+}
+
+// Tracker records the issues viewed by the sessions of this process.
+type Tracker struct {
+	mu      sync.Mutex
+	viewing map[issueKey]map[*Session]struct{}
+}
+
+// New returns a tracker without sessions.
+func New() *Tracker {
+	return &Tracker{viewing: make(map[issueKey]map[*Session]struct{})}
+}
+
+// Session is the presence of one client, which may view several issues.
+type Session struct {
+	tracker *Tracker
+	tenant  string
+	user    string
+
+	// guarded by tracker.mu
+	issues  map[int]struct{}
+	changed map[int]struct{} // viewed issues whose viewers changed since Changes
+	signal  chan struct{}
+}
+
+// Join starts a session of the user and tenant of ctx. It must be closed when the client leaves.
+func (t *Tracker) Join(ctx context.Context) *Session {
+	user, _ := auth.UserFromContext(ctx)
+	return &Session{
+		tracker: t,
+		tenant:  tenant.ID(ctx),
+		user:    user,
+		issues:  make(map[int]struct{}),
+		changed: make(map[int]struct{}),
+		signal:  make(chan struct{}, 1),
+	}
+}
+
+// View adds the issue to the ones the session is viewing.
+func (s *Session) View(issueID int) {
+	t := s.tracker
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := s.issues[issueID]; ok {
+		return
+	}
+	s.issues[issueID] = struct{}{}
+	key := issueKey{s.tenant, issueID}
+	if t.viewing[key] == nil {
+		t.viewing[key] = make(map[*Session]struct{})
+	}
+	t.viewing[key][s] = struct{}{}
+	t.notify(key)
+}
+
+// Leave removes the issue from the ones the session is viewing.
+func (s *Session) Leave(issueID int) {
+	t := s.tracker
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s.leave(issueID)
+}
+
+// Close leaves all issues. It may be called more than once.
+func (s *Session) Close() {
+	t := s.tracker
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for issueID := range s.issues {
+		s.leave(issueID)
+	}
+}
+
+// leave removes the issue from the session, tracker.mu must be held.
+func (s *Session) leave(issueID int) {
+	if _, ok := s.issues[issueID]; !ok {
+		return
+	}
+	t := s.tracker
+	delete(s.issues, issueID)
+	delete(s.changed, issueID)
+
+	key := issueKey{s.tenant, issueID}
+	delete(t.viewing[key], s)
+	if len(t.viewing[key]) == 0 {
+		delete(t.viewing, key)
+		return
+	}
+	t.notify(key)
+}
+
+// notify marks the issue as changed for its viewers, tracker.mu must be held.
+// Changes coalesce, so a session that is slow to ask for them never blocks the others.
+func (t *Tracker) notify(key issueKey) {
+	for s := range t.viewing[key] {
+		s.changed[key.issueID] = struct{}{}
+		select {
+		case s.signal <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// viewers returns the users viewing the issue, sorted and without duplicates.
+// Anonymous sessions are not listed. tracker.mu must be held.
+func (t *Tracker) viewers(key issueKey) []string {
+	users := []string{}
+	for s := range t.viewing[key] {
+		if s.user != "" {
+			users = append(users, s.user)
+		}
+	}
+	slices.Sort(users)
+	return slices.Compact(users)
+}
+
+// Changed returns a channel that receives a value when the viewers of an issue
+// the session is viewing have changed.
+func (s *Session) Changed() <-chan struct{} {
+	return s.signal
+}
+
+// Changes returns the current viewers of the issues whose viewers changed since the last call.
+func (s *Session) Changes() map[int][]string {
+	t := s.tracker
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	changes := make(map[int][]string, len(s.changed))
+	for issueID := range s.changed {
+		changes[issueID] = t.viewers(issueKey{s.tenant, issueID})
+	}
+	clear(s.changed)
+	return changes
+}
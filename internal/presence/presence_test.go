@@ -0,0 +1,75 @@
+package presence_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/presence"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func join(t *presence.Tracker, user, tenantID string) *presence.Session {
+	return t.Join(tenant.With(auth.WithUser(context.Background(), user), tenantID))
+}
+
+func TestTracker_Viewers(t *testing.T) {
+	tracker := presence.New()
+	alice := join(tracker, "alice", "acme")
+	bob := join(tracker, "bob", "acme")
+	bobAgain := join(tracker, "bob", "acme")
+	other := join(tracker, "carol", "other")
+
+	alice.View(1)
+	alice.Changes()
+	bob.View(1)
+	bobAgain.View(1)
+	other.View(1)
+
+	<-alice.Changed()
+	if got := alice.Changes(); !reflect.DeepEqual(got, map[int][]string{1: {"alice", "bob"}}) {
+		t.Fatalf("expected each viewer of the tenant once, got %v", got)
+	}
+
+	bob.Close()
+	<-alice.Changed()
+	if got := alice.Changes()[1]; !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+		t.Fatalf("expected bob to stay with another session, got %v", got)
+	}
+	bobAgain.Leave(1)
+	<-alice.Changed()
+	if got := alice.Changes()[1]; !reflect.DeepEqual(got, []string{"alice"}) {
+		t.Fatalf("expected bob to be gone, got %v", got)
+	}
+}
+
+func TestSession_ChangesCoalesce(t *testing.T) {
+	tracker := presence.New()
+	alice := join(tracker, "alice", "acme")
+	alice.View(1)
+	alice.View(2)
+
+	for _, user := range []string{"bob", "carol", "dave"} {
+		join(tracker, user, "acme").View(1)
+	}
+
+	<-alice.Changed()
+	changes := alice.Changes()
+	if len(changes) != 2 || len(changes[1]) != 4 || len(changes[2]) != 1 {
+		t.Fatalf("expected the latest viewers of both issues, got %v", changes)
+	}
+	select {
+	case <-alice.Changed():
+		if changes := alice.Changes(); len(changes) > 0 {
+			t.Fatalf("expected no further changes, got %v", changes)
+		}
+	default:
+	}
+
+	// issues left are not reported anymore
+	join(tracker, "erin", "acme").View(2)
+	alice.Leave(2)
+	if changes := alice.Changes(); len(changes) != 0 {
+		t.Fatalf("expected no changes of left issues, got %v", changes)
+	}
+}
@@ -0,0 +1,179 @@
+// Package ratelimit limits the request rate of each client with a token bucket.
+package ratelimit
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limit allows Requests per Per on average, with bursts of up to Burst requests.
+// Burst defaults to Requests.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// KeyFunc identifies the client of a request.
+type KeyFunc func(r *http.Request) string
+
+// UserOrIP identifies clients by the user the authentication middleware resolved,
+// so it must run after it, and anonymous clients by their IP address.
+func UserOrIP(r *http.Request) string {
+	return Key(r.Context(), r.RemoteAddr)
+}
+
+// Key identifies the client of ctx by its tenant and user, or by the IP address of
+// remoteAddr when it is anonymous. It is shared by the HTTP middleware and the gRPC interceptors.
+func Key(ctx context.Context, remoteAddr string) string {
+	if user, ok := auth.UserFromContext(ctx); ok {
+		return "user:" + tenant.ID(ctx) + ":" + user
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "ip:" + host
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps one token bucket per client.
+type Limiter struct {
+	limit Limit
+	rate  float64 // tokens per second
+	burst float64
+	key   KeyFunc
+	now   func() time.Time
+	idle  time.Duration // a bucket untouched this long is full again and can be forgotten
+	mu    sync.Mutex
+	byKey map[string]*bucket
+	swept time.Time
+}
+
+func New(limit Limit, key KeyFunc) *Limiter {
+	if limit.Burst <= 0 {
+		limit.Burst = limit.Requests
+	}
+
+	rate := float64(limit.Requests) / limit.Per.Seconds()
+	return &Limiter{
+		limit: limit,
+		rate:  rate,
+		burst: float64(limit.Burst),
+		key:   key,
+		now:   time.Now,
+		idle:  time.Duration(float64(limit.Burst) / rate * float64(time.Second)),
+		byKey: make(map[string]*bucket),
+	}
+}
+
+// Result is the decision of the limiter about a request.
+type Result struct {
+	Allowed    bool
+	Remaining  int           // requests left in the bucket
+	RetryAfter time.Duration // until the next request is allowed, zero while tokens are left
+	Reset      time.Duration // until the bucket is full again
+}
+
+// Allow takes a token from the bucket of the client and reports whether the request may pass.
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.byKey[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.byKey[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return Result{RetryAfter: l.refill(1 - b.tokens), Reset: l.refill(l.burst - b.tokens)}
+	}
+
+	b.tokens--
+	return Result{Allowed: true, Remaining: int(b.tokens), Reset: l.refill(l.burst - b.tokens)}
+}
+
+// refill returns how long it takes to add tokens to a bucket.
+func (l *Limiter) refill(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep forgets the buckets of clients that have been idle long enough to be full again.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < l.idle {
+		return
+	}
+
+	for key, b := range l.byKey {
+		if now.Sub(b.last) >= l.idle {
+			delete(l.byKey, key)
+		}
+	}
+	l.swept = now
+}
+
+// ExceededError reports a request over the limit.
+type ExceededError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, retry in %d seconds", seconds(e.RetryAfter))
+}
+
+// Check takes a token for the client of r like Middleware, for callers that only know
+// whether a request must be limited once they handle it, e.g. GraphQL mutations.
+// It returns an *ExceededError when the request is over the limit.
+func (l *Limiter) Check(r *http.Request) error {
+	if res := l.Allow(l.key(r)); !res.Allowed {
+		return &ExceededError{RetryAfter: res.RetryAfter}
+	}
+	return nil
+}
+
+// Middleware rejects requests over the limit with 429 and a Retry-After header.
+// All responses carry the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers,
+// the latter being the seconds until the bucket is full again.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := l.Allow(l.key(r))
+
+		h := w.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(l.limit.Burst))
+		h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(seconds(res.Reset)))
+
+		if !res.Allowed {
+			h.Set("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// seconds rounds d up to whole seconds, as used by the rate limit headers.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
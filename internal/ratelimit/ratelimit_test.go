@@ -0,0 +1,121 @@
+package ratelimit_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/ratelimit"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Limit{Requests: 1, Per: time.Hour, Burst: 2}, ratelimit.UserOrIP)
+	h := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// user stands for the caller resolved by the authentication middleware
+	send := func(remoteAddr, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/issues", nil)
+		req.RemoteAddr = remoteAddr
+		if user != "" {
+			req = req.WithContext(auth.WithUser(context.Background(), user))
+		}
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+		return res
+	}
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		res := send("10.0.0.1:1234", "")
+		if res.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status 200, got %d", i, res.Code)
+		}
+		if got := res.Header().Get("RateLimit-Remaining"); got != wantRemaining {
+			t.Fatalf("request %d: expected %s remaining, got %s", i, wantRemaining, got)
+		}
+	}
+
+	res := send("10.0.0.1:5678", "")
+	if res.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", res.Code)
+	}
+	if got := res.Header().Get("Retry-After"); got != "3600" {
+		t.Fatalf("expected Retry-After 3600, got %q", got)
+	}
+	if res.Header().Get("RateLimit-Limit") != "2" {
+		t.Fatalf("expected RateLimit-Limit 2, got %q", res.Header().Get("RateLimit-Limit"))
+	}
+
+	// other clients have their own buckets
+	if res := send("10.0.0.2:1234", ""); res.Code != http.StatusOK {
+		t.Fatalf("expected other IP to pass, got %d", res.Code)
+	}
+	if res := send("10.0.0.1:1234", "ci-bot"); res.Code != http.StatusOK {
+		t.Fatalf("expected authenticated user to pass, got %d", res.Code)
+	}
+}
+
+func TestMiddleware_Reset(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Limit{Requests: 1, Per: time.Minute, Burst: 3}, ratelimit.UserOrIP)
+	h := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, wantReset := range []string{"60", "120", "180"} {
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/issues", nil))
+		if got := res.Header().Get("RateLimit-Reset"); got != wantReset {
+			t.Fatalf("expected the bucket to be full in %s seconds, got %s", wantReset, got)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), "alice")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"anonymous", context.Background(), "ip:10.0.0.1"},
+		{"user", ctx, "user:default:alice"},
+		{"user of a tenant", tenant.With(ctx, "finance"), "user:finance:alice"},
+	}
+	for _, tt := range tests {
+		if got := ratelimit.Key(tt.ctx, "10.0.0.1:1234"); got != tt.want {
+			t.Fatalf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestAllow_Refills(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Limit{Requests: 100, Per: 100 * time.Millisecond, Burst: 1}, nil)
+
+	if !limiter.Allow("client").Allowed {
+		t.Fatal("expected first request to pass")
+	}
+	res := limiter.Allow("client")
+	if res.Allowed || res.RetryAfter <= 0 || res.RetryAfter > time.Millisecond {
+		t.Fatalf("expected second request to wait up to 1ms, got %+v", res)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if !limiter.Allow("client").Allowed {
+		t.Fatal("expected request to pass after refill")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Limit{Requests: 1, Per: time.Minute}, ratelimit.UserOrIP)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+
+	if err := limiter.Check(req); err != nil {
+		t.Fatalf("expected the first request to pass, got %v", err)
+	}
+	var exceeded *ratelimit.ExceededError
+	if err := limiter.Check(req); !errors.As(err, &exceeded) || exceeded.RetryAfter <= 0 {
+		t.Fatalf("expected the second request to be limited, got %v", err)
+	}
+}
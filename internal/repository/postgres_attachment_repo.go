@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+type PostgresAttachmentRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAttachmentRepository(db *sql.DB) *PostgresAttachmentRepository {
+	return &PostgresAttachmentRepository{db: db}
+}
+
+func (r *PostgresAttachmentRepository) CreateAttachment(ctx context.Context, attachment *model.Attachment) (int, error) {
+	query := `
+		INSERT INTO attachments (tenant_id, issue_id, filename, content_type, size, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		tenant.ID(ctx),
+		attachment.IssueID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return attachment.ID, nil
+}
+
+func (r *PostgresAttachmentRepository) GetAttachment(ctx context.Context, issueID, id int) (*model.Attachment, error) {
+	var a model.Attachment
+
+	query := `
+		SELECT id, issue_id, filename, content_type, size, storage_key, created_at
+		FROM attachments
+		WHERE issue_id = $1 AND id = $2 AND tenant_id = $3
+	`
+	err := r.db.QueryRowContext(ctx, query, issueID, id, tenant.ID(ctx)).
+		Scan(&a.ID, &a.IssueID, &a.Filename, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (r *PostgresAttachmentRepository) ListAttachments(ctx context.Context, issueID int) ([]*model.Attachment, error) {
+	query := `
+		SELECT id, issue_id, filename, content_type, size, storage_key, created_at
+		FROM attachments
+		WHERE issue_id = $1 AND tenant_id = $2
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, issueID, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*model.Attachment
+	for rows.Next() {
+		var a model.Attachment
+		err := rows.Scan(&a.ID, &a.IssueID, &a.Filename, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// ListAttachmentsForIssues returns the attachments of all given issues with one query,
+// ordered by issue and ID.
+func (r *PostgresAttachmentRepository) ListAttachmentsForIssues(ctx context.Context, issueIDs []int) ([]*model.Attachment, error) {
+	query := `
+		SELECT id, issue_id, filename, content_type, size, storage_key, created_at
+		FROM attachments
+		WHERE issue_id = ANY($1) AND tenant_id = $2
+		ORDER BY issue_id, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(issueIDs), tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*model.Attachment
+	for rows.Next() {
+		var a model.Attachment
+		err := rows.Scan(&a.ID, &a.IssueID, &a.Filename, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
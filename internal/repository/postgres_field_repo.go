@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+type PostgresFieldRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresFieldRepository(db *sql.DB) *PostgresFieldRepository {
+	return &PostgresFieldRepository{db: db}
+}
+
+func (r *PostgresFieldRepository) CreateField(ctx context.Context, field *model.FieldDefinition) (int, error) {
+	var id int
+	query := `
+		INSERT INTO custom_fields (tenant_id, name, type, options, required)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	err := r.db.QueryRowContext(ctx, query, tenant.ID(ctx), field.Name, field.Type, pq.Array(field.Options), field.Required).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, model.ErrConflict
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *PostgresFieldRepository) GetFieldByID(ctx context.Context, id int) (*model.FieldDefinition, error) {
+	var field model.FieldDefinition
+
+	query := "SELECT id, name, type, options, required FROM custom_fields WHERE id = $1 AND tenant_id = $2"
+	err := r.db.QueryRowContext(ctx, query, id, tenant.ID(ctx)).Scan(&field.ID, &field.Name, &field.Type, pq.Array(&field.Options), &field.Required)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &field, nil
+}
+
+func (r *PostgresFieldRepository) DeleteField(ctx context.Context, id int) error {
+	query := "DELETE FROM custom_fields WHERE id = $1 AND tenant_id = $2"
+	result, err := r.db.ExecContext(ctx, query, id, tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return model.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresFieldRepository) ListFields(ctx context.Context) ([]*model.FieldDefinition, error) {
+	query := "SELECT id, name, type, options, required FROM custom_fields WHERE tenant_id = $1 ORDER BY id"
+	rows, err := r.db.QueryContext(ctx, query, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []*model.FieldDefinition
+	for rows.Next() {
+		var field model.FieldDefinition
+		err := rows.Scan(&field.ID, &field.Name, &field.Type, pq.Array(&field.Options), &field.Required)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, &field)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"database/sql"
+)
+
+type PostgresIdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresIdempotencyRepository(db *sql.DB) *PostgresIdempotencyRepository {
+	return &PostgresIdempotencyRepository{db: db}
+}
+
+// ClaimIdempotencyKey stores the record as pending unless a record with the same key exists,
+// in which case the existing record is returned. Expired records are removed first.
+func (r *PostgresIdempotencyRepository) ClaimIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= NOW()"); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO idempotency_keys (scope, key, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (scope, key) DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query, record.Scope, record.Key, record.RequestHash, record.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if inserted, err := result.RowsAffected(); err != nil || inserted > 0 {
+		return nil, err
+	}
+
+	var existing model.IdempotencyRecord
+	query = `
+		SELECT scope, key, request_hash, status_code, content_type, body, expires_at
+		FROM idempotency_keys
+		WHERE scope = $1 AND key = $2
+	`
+	err = r.db.QueryRowContext(ctx, query, record.Scope, record.Key).Scan(
+		&existing.Scope,
+		&existing.Key,
+		&existing.RequestHash,
+		&existing.StatusCode,
+		&existing.ContentType,
+		&existing.Body,
+		&existing.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &existing, nil
+}
+
+// CompleteIdempotencyKey stores the response of a claimed key.
+func (r *PostgresIdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) error {
+	query := `UPDATE idempotency_keys
+		SET status_code = $1,
+			content_type = $2,
+			body = $3
+		WHERE scope = $4 AND key = $5;`
+
+	result, err := r.db.ExecContext(ctx, query, record.StatusCode, record.ContentType, record.Body, record.Scope, record.Key)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+func (r *PostgresIdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, scope, key string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2", scope, key)
+	return err
+}
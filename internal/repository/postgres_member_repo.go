@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"database/sql"
+)
+
+type PostgresMemberRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresMemberRepository(db *sql.DB) *PostgresMemberRepository {
+	return &PostgresMemberRepository{db: db}
+}
+
+// SetMember assigns the role to the user, replacing the current one.
+func (r *PostgresMemberRepository) SetMember(ctx context.Context, member *model.Member) error {
+	query := `
+		INSERT INTO members (tenant_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = NOW()
+		RETURNING updated_at
+	`
+	return r.db.QueryRowContext(ctx, query, tenant.ID(ctx), member.User, member.Role).Scan(&member.UpdatedAt)
+}
+
+func (r *PostgresMemberRepository) GetMember(ctx context.Context, user string) (*model.Member, error) {
+	var m model.Member
+
+	query := "SELECT user_id, role, updated_at FROM members WHERE user_id = $1 AND tenant_id = $2"
+	err := r.db.QueryRowContext(ctx, query, user, tenant.ID(ctx)).Scan(&m.User, &m.Role, &m.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (r *PostgresMemberRepository) DeleteMember(ctx context.Context, user string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM members WHERE user_id = $1 AND tenant_id = $2", user, tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+func (r *PostgresMemberRepository) ListMembers(ctx context.Context) ([]*model.Member, error) {
+	query := "SELECT user_id, role, updated_at FROM members WHERE tenant_id = $1 ORDER BY user_id"
+	rows, err := r.db.QueryContext(ctx, query, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*model.Member
+	for rows.Next() {
+		var m model.Member
+		if err := rows.Scan(&m.User, &m.Role, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
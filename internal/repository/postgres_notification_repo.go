@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const notificationColumns = "id, email, issue_id, event, payload, digest, attempts, created_at"
+
+type PostgresNotificationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresNotificationRepository(db *sql.DB) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{db: db}
+}
+
+func (r *PostgresNotificationRepository) CreateSubscription(ctx context.Context, subscription *model.EmailSubscription) (int, error) {
+	query := `
+		INSERT INTO issue_email_subscriptions (tenant_id, issue_id, email, digest)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, tenant.ID(ctx), subscription.IssueID, subscription.Email, subscription.Digest).
+		Scan(&subscription.ID, &subscription.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, model.ErrConflict
+		}
+		return 0, err
+	}
+
+	return subscription.ID, nil
+}
+
+func (r *PostgresNotificationRepository) DeleteSubscription(ctx context.Context, issueID, id int) error {
+	query := "DELETE FROM issue_email_subscriptions WHERE issue_id = $1 AND id = $2 AND tenant_id = $3"
+	result, err := r.db.ExecContext(ctx, query, issueID, id, tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+func (r *PostgresNotificationRepository) DeleteIssueSubscriptions(ctx context.Context, issueID int) error {
+	query := "DELETE FROM issue_email_subscriptions WHERE issue_id = $1 AND tenant_id = $2"
+	_, err := r.db.ExecContext(ctx, query, issueID, tenant.ID(ctx))
+	return err
+}
+
+func (r *PostgresNotificationRepository) ListSubscriptions(ctx context.Context, issueID int) ([]*model.EmailSubscription, error) {
+	query := `
+		SELECT id, issue_id, email, digest, created_at
+		FROM issue_email_subscriptions
+		WHERE issue_id = $1 AND tenant_id = $2
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, issueID, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*model.EmailSubscription
+	for rows.Next() {
+		var s model.EmailSubscription
+		if err := rows.Scan(&s.ID, &s.IssueID, &s.Email, &s.Digest, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// ListSubscriptionsForIssues returns the subscriptions of all given issues with one query,
+// ordered by issue and ID.
+func (r *PostgresNotificationRepository) ListSubscriptionsForIssues(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error) {
+	query := `
+		SELECT id, issue_id, email, digest, created_at
+		FROM issue_email_subscriptions
+		WHERE issue_id = ANY($1) AND tenant_id = $2
+		ORDER BY issue_id, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(issueIDs), tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*model.EmailSubscription
+	for rows.Next() {
+		var s model.EmailSubscription
+		if err := rows.Scan(&s.ID, &s.IssueID, &s.Email, &s.Digest, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// EnqueueNotifications queues the event for every subscriber of the issue.
+func (r *PostgresNotificationRepository) EnqueueNotifications(ctx context.Context, issueID int, event string, payload []byte) error {
+	query := `
+		INSERT INTO email_notifications (tenant_id, email, issue_id, event, payload, digest)
+		SELECT tenant_id, email, issue_id, $2::text, $3::jsonb, digest
+		FROM issue_email_subscriptions
+		WHERE issue_id = $1 AND tenant_id = $4
+	`
+	_, err := r.db.ExecContext(ctx, query, issueID, event, payload, tenant.ID(ctx))
+	return err
+}
+
+// ClaimPendingNotifications returns up to limit unsent notifications of subscribers who want
+// an email per change and postpones them by lease, so concurrent workers do not send them twice.
+func (r *PostgresNotificationRepository) ClaimPendingNotifications(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]*model.EmailNotification, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM email_notifications
+			WHERE sent_at IS NULL AND NOT digest AND attempts < $2 AND next_attempt_at <= NOW()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE email_notifications n
+			SET next_attempt_at = NOW() + make_interval(secs => $3)
+			FROM due
+			WHERE n.id = due.id
+			RETURNING n.*
+		)
+		SELECT ` + notificationColumns + `
+		FROM claimed
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, maxAttempts, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotifications(rows)
+}
+
+// ClaimDueDigestNotifications returns the unsent digest notifications of up to limit subscribers
+// whose oldest unsent notification was queued before the given time, unless a failed or claimed
+// digest of the subscriber waits for its next attempt, and postpones them by lease like
+// ClaimPendingNotifications.
+func (r *PostgresNotificationRepository) ClaimDueDigestNotifications(ctx context.Context, before time.Time, limit, maxAttempts int, lease time.Duration) ([]*model.EmailNotification, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM email_notifications
+			WHERE sent_at IS NULL AND digest AND attempts < $3
+			AND email IN (
+				SELECT email FROM email_notifications
+				WHERE sent_at IS NULL AND digest AND attempts < $3
+				GROUP BY email
+				HAVING MIN(created_at) <= $1 AND MAX(next_attempt_at) <= NOW()
+				ORDER BY MIN(created_at)
+				LIMIT $2
+			)
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE email_notifications n
+			SET next_attempt_at = NOW() + make_interval(secs => $4)
+			FROM due
+			WHERE n.id = due.id
+			RETURNING n.*
+		)
+		SELECT ` + notificationColumns + `
+		FROM claimed
+		ORDER BY email, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, before, limit, maxAttempts, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotifications(rows)
+}
+
+func (r *PostgresNotificationRepository) MarkNotificationsSent(ctx context.Context, ids []int) error {
+	query := "UPDATE email_notifications SET sent_at = NOW() WHERE id = ANY($1)"
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ids))
+	return err
+}
+
+// RecordNotificationFailure counts a failed attempt and schedules the next one.
+func (r *PostgresNotificationRepository) RecordNotificationFailure(ctx context.Context, ids []int, reason string, nextAttemptAt time.Time) error {
+	query := "UPDATE email_notifications SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = ANY($1)"
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ids), reason, nextAttemptAt)
+	return err
+}
+
+func scanNotifications(rows *sql.Rows) ([]*model.EmailNotification, error) {
+	var notifications []*model.EmailNotification
+	for rows.Next() {
+		var n model.EmailNotification
+		var payload []byte
+		err := rows.Scan(&n.ID, &n.Email, &n.IssueID, &n.Event, &payload, &n.Digest, &n.Attempts, &n.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		n.Payload = payload
+		notifications = append(notifications, &n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
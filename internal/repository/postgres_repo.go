@@ -3,10 +3,25 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"Go-IssueTracker-API/internal/logging"
 	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"Go-IssueTracker-API/internal/tracing"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("Go-IssueTracker-API/internal/repository")
+
+const issueColumns = "id, title, description, status, reporter, assignee, updated_by, custom_fields"
+
 type PostgresIssueRepository struct {
 	db *sql.DB
 }
@@ -15,14 +30,22 @@ func NewPostgresIssueRepository(db *sql.DB) *PostgresIssueRepository {
 	return &PostgresIssueRepository{db: db}
 }
 
-func (r *PostgresIssueRepository) CreateIssue(ctx context.Context, issue *model.Issue) (int, error) {
+func (r *PostgresIssueRepository) CreateIssue(ctx context.Context, issue *model.Issue) (_ int, err error) {
+	customFields, err := marshalCustomFields(issue.CustomFields)
+	if err != nil {
+		return 0, err
+	}
+
 	var id int
 	query := `
-		INSERT INTO issues (title, description, status)
-		VALUES ($1, $2, $3)
+		INSERT INTO issues (tenant_id, title, description, status, reporter, assignee, updated_by, custom_fields)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
-	err := r.db.QueryRowContext(ctx, query, issue.Title, issue.Description, issue.Status).Scan(&id)
+	ctx, end := startSpan(ctx, "PostgresIssueRepository.CreateIssue", query)
+	defer end(&err)
+
+	err = r.db.QueryRowContext(ctx, query, tenant.ID(ctx), issue.Title, issue.Description, issue.Status, issue.Reporter, issue.Assignee, issue.UpdatedBy, customFields).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -30,31 +53,42 @@ func (r *PostgresIssueRepository) CreateIssue(ctx context.Context, issue *model.
 	return id, nil
 }
 
-func (r *PostgresIssueRepository) GetIssueByID(ctx context.Context, id int) (*model.Issue, error) {
-	var issue model.Issue
+func (r *PostgresIssueRepository) GetIssueByID(ctx context.Context, id int) (_ *model.Issue, err error) {
+	query := "SELECT " + issueColumns + " FROM issues WHERE id = $1 AND tenant_id = $2"
+	ctx, end := startSpan(ctx, "PostgresIssueRepository.GetIssueByID", query)
+	defer end(&err)
 
-	query := "SELECT id, title, description, status FROM issues WHERE id = $1"
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&issue.ID, &issue.Title, &issue.Description, &issue.Status)
+	issue, err := scanIssue(r.db.QueryRowContext(ctx, query, id, tenant.ID(ctx)))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("not found")
+			return nil, model.ErrNotFound
 		}
 		return nil, err
 	}
 
-	return &issue, nil
+	return issue, nil
 }
 
-func (r *PostgresIssueRepository) UpdateIssue(ctx context.Context, issue *model.Issue) error {
+func (r *PostgresIssueRepository) UpdateIssue(ctx context.Context, issue *model.Issue) (err error) {
+	customFields, err := marshalCustomFields(issue.CustomFields)
+	if err != nil {
+		return err
+	}
+
 	query := `UPDATE issues
 		SET title = $1,
 			description = $2,
-			status = $3
-		WHERE id = $4;`
+			status = $3,
+			assignee = $4,
+			updated_by = $5,
+			custom_fields = $6
+		WHERE id = $7 AND tenant_id = $8;`
+	ctx, end := startSpan(ctx, "PostgresIssueRepository.UpdateIssue", query)
+	defer end(&err)
 
-	result, err := r.db.ExecContext(ctx, query, issue.Title, issue.Description, issue.Status, issue.ID)
+	result, err := r.db.ExecContext(ctx, query, issue.Title, issue.Description, issue.Status, issue.Assignee, issue.UpdatedBy, customFields, issue.ID, tenant.ID(ctx))
 	if err != nil {
-		return errors.New("invalid request")
+		return model.ErrInvalidInput
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -63,15 +97,18 @@ func (r *PostgresIssueRepository) UpdateIssue(ctx context.Context, issue *model.
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("not found")
+		return model.ErrNotFound
 	}
 
 	return nil
 }
 
-func (r *PostgresIssueRepository) DeleteIssue(ctx context.Context, id int) error {
-	query := "DELETE FROM issues WHERE id = $1"
-	result, err := r.db.ExecContext(ctx, query, id)
+func (r *PostgresIssueRepository) DeleteIssue(ctx context.Context, id int) (err error) {
+	query := "DELETE FROM issues WHERE id = $1 AND tenant_id = $2"
+	ctx, end := startSpan(ctx, "PostgresIssueRepository.DeleteIssue", query)
+	defer end(&err)
+
+	result, err := r.db.ExecContext(ctx, query, id, tenant.ID(ctx))
 	if err != nil {
 		return err
 	}
@@ -82,15 +119,31 @@ func (r *PostgresIssueRepository) DeleteIssue(ctx context.Context, id int) error
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("not found")
+		return model.ErrNotFound
 	}
 
 	return nil
 }
 
-func (r *PostgresIssueRepository) ListIssues(ctx context.Context) ([]*model.Issue, error) {
-	query := "SELECT id, title, description, status FROM issues"
-	rows, err := r.db.QueryContext(ctx, query)
+func (r *PostgresIssueRepository) ListIssues(ctx context.Context, filter model.IssueFilter) (_ []*model.Issue, err error) {
+	where, args, err := issueConditions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.AfterID > 0 {
+		args = append(args, filter.AfterID)
+		where += fmt.Sprintf(" AND id > $%d", len(args))
+	}
+
+	query := "SELECT " + issueColumns + " FROM issues WHERE " + where + " ORDER BY id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	ctx, end := startSpan(ctx, "PostgresIssueRepository.ListIssues", query)
+	defer end(&err)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,17 +151,109 @@ func (r *PostgresIssueRepository) ListIssues(ctx context.Context) ([]*model.Issu
 
 	var issues []*model.Issue
 	for rows.Next() {
-		var issue model.Issue
-		err := rows.Scan(&issue.ID, &issue.Title, &issue.Description, &issue.Status)
+		issue, err := scanIssue(rows)
 		if err != nil {
 			return nil, err
 		}
-		issues = append(issues, &issue)
+		issues = append(issues, issue)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("db.response.returned_rows", len(issues)))
 	return issues, nil
-}
\ No newline at end of file
+}
+
+// CountIssues returns the number of issues matching the filter on all pages.
+func (r *PostgresIssueRepository) CountIssues(ctx context.Context, filter model.IssueFilter) (_ int, err error) {
+	where, args, err := issueConditions(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "SELECT COUNT(*) FROM issues WHERE " + where
+	ctx, end := startSpan(ctx, "PostgresIssueRepository.CountIssues", query)
+	defer end(&err)
+
+	var count int
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// issueConditions returns the WHERE clause selecting the issues of the tenant that match the filter.
+func issueConditions(ctx context.Context, filter model.IssueFilter) (string, []any, error) {
+	conditions := []string{"tenant_id = $1"}
+	args := []any{tenant.ID(ctx)}
+
+	equal := []struct{ column, value string }{
+		{"status", filter.Status},
+		{"assignee", filter.Assignee},
+		{"reporter", filter.Reporter},
+	}
+	for _, e := range equal {
+		if e.value != "" {
+			args = append(args, e.value)
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", e.column, len(args)))
+		}
+	}
+
+	if len(filter.CustomFields) > 0 {
+		data, err := json.Marshal(filter.CustomFields)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, data)
+		conditions = append(conditions, fmt.Sprintf("custom_fields @> $%d", len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// startSpan starts a client span for a statement, with the statement text as attribute.
+// Values are passed as parameters, so the text contains no user data.
+// The returned function ends the span and logs the statement if it failed.
+func startSpan(ctx context.Context, name, query string) (context.Context, func(*error)) {
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBQueryText(strings.TrimSpace(query))),
+	)
+
+	return ctx, func(err *error) {
+		if *err != nil && !errors.Is(*err, model.ErrNotFound) {
+			logging.FromContext(ctx).Error("statement failed", "operation", name, "error", *err)
+		}
+		tracing.End(span, err)
+	}
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanIssue(row rowScanner) (*model.Issue, error) {
+	var issue model.Issue
+	var customFields []byte
+
+	err := row.Scan(&issue.ID, &issue.Title, &issue.Description, &issue.Status, &issue.Reporter, &issue.Assignee, &issue.UpdatedBy, &customFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(customFields) > 0 {
+		if err := json.Unmarshal(customFields, &issue.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
+	return &issue, nil
+}
+
+func marshalCustomFields(fields map[string]any) ([]byte, error) {
+	if fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(fields)
+}
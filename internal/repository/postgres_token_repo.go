@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const tokenColumns = "id, name, user_id, tenant_id, scopes, token_hash, expires_at, last_used_at, revoked_at, created_at"
+
+type PostgresTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTokenRepository(db *sql.DB) *PostgresTokenRepository {
+	return &PostgresTokenRepository{db: db}
+}
+
+func (r *PostgresTokenRepository) CreateToken(ctx context.Context, token *model.APIToken) (int, error) {
+	query := `
+		INSERT INTO api_tokens (name, user_id, tenant_id, scopes, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, token.Name, token.User, token.Tenant, pq.Array(token.Scopes), token.Hash, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return token.ID, nil
+}
+
+// GetTokenByHash looks the token up in all tenants, the tenant of a request is only known after authentication.
+func (r *PostgresTokenRepository) GetTokenByHash(ctx context.Context, hash string) (*model.APIToken, error) {
+	query := "SELECT " + tokenColumns + " FROM api_tokens WHERE token_hash = $1"
+	token, err := scanToken(r.db.QueryRowContext(ctx, query, hash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *PostgresTokenRepository) ListTokens(ctx context.Context, user string) ([]*model.APIToken, error) {
+	query := "SELECT " + tokenColumns + " FROM api_tokens WHERE user_id = $1 AND tenant_id = $2 ORDER BY id"
+	rows, err := r.db.QueryContext(ctx, query, user, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*model.APIToken
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RevokeToken revokes a token of the user. Revoking a token twice reports model.ErrNotFound.
+func (r *PostgresTokenRepository) RevokeToken(ctx context.Context, user string, id int) error {
+	query := "UPDATE api_tokens SET revoked_at = NOW() WHERE id = $1 AND user_id = $2 AND tenant_id = $3 AND revoked_at IS NULL"
+	result, err := r.db.ExecContext(ctx, query, id, user, tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+func (r *PostgresTokenRepository) TouchToken(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+func scanToken(row rowScanner) (*model.APIToken, error) {
+	var t model.APIToken
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+
+	err := row.Scan(&t.ID, &t.Name, &t.User, &t.Tenant, pq.Array(&t.Scopes), &t.Hash, &expiresAt, &lastUsedAt, &revokedAt, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		t.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		t.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+
+	return &t, nil
+}
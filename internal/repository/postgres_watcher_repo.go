@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"database/sql"
+)
+
+type PostgresWatcherRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWatcherRepository(db *sql.DB) *PostgresWatcherRepository {
+	return &PostgresWatcherRepository{db: db}
+}
+
+// AddWatcher subscribes the user to the issue. Watching an issue twice is not an error.
+func (r *PostgresWatcherRepository) AddWatcher(ctx context.Context, issueID int, user string) error {
+	query := `
+		INSERT INTO issue_watchers (tenant_id, issue_id, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, tenant.ID(ctx), issueID, user)
+	return err
+}
+
+func (r *PostgresWatcherRepository) RemoveWatcher(ctx context.Context, issueID int, user string) error {
+	query := "DELETE FROM issue_watchers WHERE issue_id = $1 AND user_id = $2 AND tenant_id = $3"
+	_, err := r.db.ExecContext(ctx, query, issueID, user, tenant.ID(ctx))
+	return err
+}
+
+func (r *PostgresWatcherRepository) ListWatchedIssues(ctx context.Context, user string) ([]*model.Issue, error) {
+	query := `
+		SELECT ` + issueColumns + `
+		FROM issues
+		WHERE tenant_id = $2 AND id IN (SELECT issue_id FROM issue_watchers WHERE user_id = $1 AND tenant_id = $2)
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, user, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var issues []*model.Issue
+	for rows.Next() {
+		issue, err := scanIssue(rows)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
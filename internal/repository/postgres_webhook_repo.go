@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const deliveryColumns = "id, webhook_id, tenant_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at"
+
+type PostgresWebhookRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWebhookRepository(db *sql.DB) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{db: db}
+}
+
+func (r *PostgresWebhookRepository) CreateWebhook(ctx context.Context, webhook *model.Webhook) (int, error) {
+	query := `
+		INSERT INTO webhooks (tenant_id, url, secret, events, active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, tenant.ID(ctx), webhook.URL, webhook.Secret, pq.Array(webhook.Events), webhook.Active).
+		Scan(&webhook.ID, &webhook.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return webhook.ID, nil
+}
+
+func (r *PostgresWebhookRepository) GetWebhook(ctx context.Context, id int) (*model.Webhook, error) {
+	var w model.Webhook
+
+	query := "SELECT id, url, secret, events, active, created_at FROM webhooks WHERE id = $1 AND tenant_id = $2"
+	err := r.db.QueryRowContext(ctx, query, id, tenant.ID(ctx)).
+		Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Events), &w.Active, &w.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (r *PostgresWebhookRepository) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	query := `UPDATE webhooks
+		SET url = $1,
+			secret = $2,
+			events = $3,
+			active = $4
+		WHERE id = $5 AND tenant_id = $6;`
+
+	result, err := r.db.ExecContext(ctx, query, webhook.URL, webhook.Secret, pq.Array(webhook.Events), webhook.Active, webhook.ID, tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+func (r *PostgresWebhookRepository) DeleteWebhook(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = $1 AND tenant_id = $2", id, tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+func (r *PostgresWebhookRepository) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	query := "SELECT id, url, secret, events, active, created_at FROM webhooks WHERE tenant_id = $1 ORDER BY id"
+	rows, err := r.db.QueryContext(ctx, query, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*model.Webhook
+	for rows.Next() {
+		var w model.Webhook
+		err := rows.Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Events), &w.Active, &w.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, &w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// EnqueueDeliveries queues the payload for every active webhook of the tenant subscribed to the event.
+func (r *PostgresWebhookRepository) EnqueueDeliveries(ctx context.Context, event string, payload []byte) error {
+	query := `
+		INSERT INTO webhook_deliveries (tenant_id, webhook_id, event, payload)
+		SELECT tenant_id, id, $1::text, $2::jsonb
+		FROM webhooks
+		WHERE tenant_id = $3 AND active AND (cardinality(events) = 0 OR $1 = ANY(events))
+	`
+	_, err := r.db.ExecContext(ctx, query, event, payload, tenant.ID(ctx))
+	return err
+}
+
+func (r *PostgresWebhookRepository) ListDeliveries(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error) {
+	query := "SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE webhook_id = $1 AND tenant_id = $2 ORDER BY id DESC"
+	rows, err := r.db.QueryContext(ctx, query, webhookID, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+func (r *PostgresWebhookRepository) ListDeliveryAttempts(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error) {
+	query := `
+		SELECT a.id, a.delivery_id, a.status_code, a.error, a.duration_ms, a.attempted_at
+		FROM webhook_delivery_attempts a
+		JOIN webhook_deliveries d ON d.id = a.delivery_id
+		WHERE d.webhook_id = $1 AND a.delivery_id = $2 AND d.tenant_id = $3
+		ORDER BY a.id
+	`
+	rows, err := r.db.QueryContext(ctx, query, webhookID, deliveryID, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*model.DeliveryAttempt
+	for rows.Next() {
+		var a model.DeliveryAttempt
+		err := rows.Scan(&a.ID, &a.DeliveryID, &a.StatusCode, &a.Error, &a.DurationMS, &a.AttemptedAt)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, &a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries whose next attempt is due
+// and postpones them by lease, so concurrent workers do not send them twice.
+func (r *PostgresWebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM due
+		WHERE d.id = due.id
+		RETURNING d.id, d.webhook_id, d.tenant_id, d.event, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+// RecordDeliveryAttempt stores the attempt and moves the delivery to its new status.
+func (r *PostgresWebhookRepository) RecordDeliveryAttempt(ctx context.Context, attempt *model.DeliveryAttempt, status string, nextAttemptAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO webhook_delivery_attempts (tenant_id, delivery_id, status_code, error, duration_ms, attempted_at)
+		SELECT tenant_id, id, $2, $3, $4, $5
+		FROM webhook_deliveries
+		WHERE id = $1
+		RETURNING id
+	`
+	err = tx.QueryRowContext(ctx, query, attempt.DeliveryID, attempt.StatusCode, attempt.Error, attempt.DurationMS, attempt.AttemptedAt).
+		Scan(&attempt.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `UPDATE webhook_deliveries
+		SET status = $1,
+			attempts = attempts + 1,
+			next_attempt_at = $2,
+			last_status_code = $3,
+			last_error = $4
+		WHERE id = $5;`
+
+	_, err = tx.ExecContext(ctx, query, status, nextAttemptAt, attempt.StatusCode, attempt.Error, attempt.DeliveryID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func scanDeliveries(rows *sql.Rows) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		var payload []byte
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.Tenant, &d.Event, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		d.Payload = payload
+		deliveries = append(deliveries, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// expectAffected reports model.ErrNotFound when a statement did not touch any row.
+func expectAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return model.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+type AttachmentService struct {
+	repo   AttachmentRepository
+	issues IssueRepository
+	blobs  BlobStore
+}
+
+func NewAttachmentService(repo AttachmentRepository, issues IssueRepository, blobs BlobStore) *AttachmentService {
+	return &AttachmentService{repo: repo, issues: issues, blobs: blobs}
+}
+
+// UploadAttachment stores the file contents in the blob store and records its metadata.
+// The size of the content does not need to be known in advance, it is counted while uploading.
+func (s *AttachmentService) UploadAttachment(ctx context.Context, attachment *model.Attachment, content io.Reader) (int, error) {
+	attachment.Filename = path.Base(strings.ReplaceAll(attachment.Filename, `\`, "/"))
+	if attachment.Filename == "" || attachment.Filename == "." || attachment.Filename == "/" {
+		return 0, fmt.Errorf("%w: filename is required", model.ErrInvalidInput)
+	}
+
+	if attachment.ContentType == "" {
+		attachment.ContentType = "application/octet-stream"
+	}
+
+	if _, err := s.issues.GetIssueByID(ctx, attachment.IssueID); err != nil {
+		return 0, err
+	}
+
+	attachment.StorageKey = fmt.Sprintf("issues/%d/%s", attachment.IssueID, rand.Text())
+
+	counter := &countingReader{r: content}
+	err := s.blobs.Put(ctx, attachment.StorageKey, counter, -1, attachment.ContentType)
+	if err != nil {
+		return 0, err
+	}
+	attachment.Size = counter.n
+
+	id, err := s.repo.CreateAttachment(ctx, attachment)
+	if err != nil {
+		// do not leave unreferenced blobs behind
+		s.blobs.Delete(context.WithoutCancel(ctx), attachment.StorageKey)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetAttachment returns the attachment metadata and a reader of its contents.
+// The caller must close the reader.
+func (s *AttachmentService) GetAttachment(ctx context.Context, issueID, id int) (*model.Attachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetAttachment(ctx, issueID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, issueID int) ([]*model.Attachment, error) {
+	if _, err := s.issues.GetIssueByID(ctx, issueID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListAttachments(ctx, issueID)
+}
+
+// ListAttachmentsForIssues returns the attachments of several issues at once, ordered by issue.
+func (s *AttachmentService) ListAttachmentsForIssues(ctx context.Context, issueIDs []int) ([]*model.Attachment, error) {
+	return s.repo.ListAttachmentsForIssues(ctx, issueIDs)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
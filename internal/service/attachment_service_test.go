@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type MockAttachmentRepo struct {
+	CreateFunc func(ctx context.Context, attachment *model.Attachment) (int, error)
+	GetFunc    func(ctx context.Context, issueID, id int) (*model.Attachment, error)
+	ListFunc   func(ctx context.Context, issueID int) ([]*model.Attachment, error)
+	BatchFunc  func(ctx context.Context, issueIDs []int) ([]*model.Attachment, error)
+}
+
+func (m *MockAttachmentRepo) CreateAttachment(ctx context.Context, attachment *model.Attachment) (int, error) {
+	return m.CreateFunc(ctx, attachment)
+}
+
+func (m *MockAttachmentRepo) GetAttachment(ctx context.Context, issueID, id int) (*model.Attachment, error) {
+	return m.GetFunc(ctx, issueID, id)
+}
+
+func (m *MockAttachmentRepo) ListAttachments(ctx context.Context, issueID int) ([]*model.Attachment, error) {
+	return m.ListFunc(ctx, issueID)
+}
+
+func (m *MockAttachmentRepo) ListAttachmentsForIssues(ctx context.Context, issueIDs []int) ([]*model.Attachment, error) {
+	return m.BatchFunc(ctx, issueIDs)
+}
+
+// MemoryBlobStore is an in-memory BlobStore.
+type MemoryBlobStore struct {
+	blobs map[string]string
+}
+
+func (m *MemoryBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if m.blobs == nil {
+		m.blobs = make(map[string]string)
+	}
+	m.blobs[key] = string(data)
+	return nil
+}
+
+func (m *MemoryBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, model.ErrNotFound
+	}
+	return io.NopCloser(strings.NewReader(data)), nil
+}
+
+func (m *MemoryBlobStore) Delete(ctx context.Context, key string) error {
+	delete(m.blobs, key)
+	return nil
+}
+
+func existingIssueRepo() *MockRepo {
+	return &MockRepo{
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			return &model.Issue{ID: id}, nil
+		},
+	}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	var saved *model.Attachment
+	mockRepo := &MockAttachmentRepo{
+		CreateFunc: func(ctx context.Context, attachment *model.Attachment) (int, error) {
+			saved = attachment
+			return 1, nil
+		},
+	}
+	blobs := &MemoryBlobStore{}
+
+	service := service.NewAttachmentService(mockRepo, existingIssueRepo(), blobs)
+
+	attachment := &model.Attachment{IssueID: 5, Filename: `C:\logs\app.log`}
+	_, err := service.UploadAttachment(context.Background(), attachment, strings.NewReader("panic: boom"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if saved == nil {
+		t.Fatal("expected CreateAttachment to be called")
+	}
+
+	if saved.Filename != "app.log" {
+		t.Fatalf("expected filename without directories, got %q", saved.Filename)
+	}
+
+	if saved.Size != int64(len("panic: boom")) {
+		t.Fatalf("expected size to be counted, got %d", saved.Size)
+	}
+
+	if saved.ContentType != "application/octet-stream" {
+		t.Fatalf("expected default content type, got %q", saved.ContentType)
+	}
+
+	if blobs.blobs[saved.StorageKey] != "panic: boom" {
+		t.Fatalf("expected content to be stored under %q", saved.StorageKey)
+	}
+}
+
+func TestUploadAttachment_RepoErrorRemovesBlob(t *testing.T) {
+	expErr := errors.New("db is down")
+	mockRepo := &MockAttachmentRepo{
+		CreateFunc: func(ctx context.Context, attachment *model.Attachment) (int, error) {
+			return 0, expErr
+		},
+	}
+	blobs := &MemoryBlobStore{}
+
+	service := service.NewAttachmentService(mockRepo, existingIssueRepo(), blobs)
+
+	attachment := &model.Attachment{IssueID: 5, Filename: "app.log"}
+	_, err := service.UploadAttachment(context.Background(), attachment, strings.NewReader("data"))
+	if !errors.Is(err, expErr) {
+		t.Fatalf("expected %v, got %v", expErr, err)
+	}
+
+	if len(blobs.blobs) != 0 {
+		t.Fatalf("expected blob to be removed, got %v", blobs.blobs)
+	}
+}
+
+func TestUploadAttachment_IssueNotFound(t *testing.T) {
+	issues := &MockRepo{
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			return nil, model.ErrNotFound
+		},
+	}
+	blobs := &MemoryBlobStore{}
+
+	service := service.NewAttachmentService(&MockAttachmentRepo{}, issues, blobs)
+
+	attachment := &model.Attachment{IssueID: 5, Filename: "app.log"}
+	_, err := service.UploadAttachment(context.Background(), attachment, strings.NewReader("data"))
+	if !errors.Is(err, model.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestDeleteIssue_RemovesAttachmentBlobs(t *testing.T) {
+	blobs := &MemoryBlobStore{blobs: map[string]string{
+		"issues/1/a": "log",
+		"issues/1/b": "screenshot",
+		"issues/2/c": "other issue",
+	}}
+	attachments := &MockAttachmentRepo{
+		ListFunc: func(ctx context.Context, issueID int) ([]*model.Attachment, error) {
+			return []*model.Attachment{{ID: 1, StorageKey: "issues/1/a"}, {ID: 2, StorageKey: "issues/1/b"}}, nil
+		},
+	}
+	deleted := false
+	issues := &MockRepo{
+		DeleteFunc: func(ctx context.Context, id int) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	svc := service.NewIssueService(issues, service.WithAttachments(attachments, blobs))
+	if err := svc.DeleteIssue(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if !deleted {
+		t.Fatal("expected the issue to be deleted")
+	}
+	if len(blobs.blobs) != 1 || blobs.blobs["issues/2/c"] == "" {
+		t.Fatalf("expected only the blobs of issue 1 to be removed, got %v", blobs.blobs)
+	}
+}
+
+func TestDeleteIssue_KeepsBlobsWhenDeleteFails(t *testing.T) {
+	blobs := &MemoryBlobStore{blobs: map[string]string{"issues/1/a": "log"}}
+	attachments := &MockAttachmentRepo{
+		ListFunc: func(ctx context.Context, issueID int) ([]*model.Attachment, error) {
+			return []*model.Attachment{{ID: 1, StorageKey: "issues/1/a"}}, nil
+		},
+	}
+	issues := &MockRepo{
+		DeleteFunc: func(ctx context.Context, id int) error {
+			return model.ErrNotFound
+		},
+	}
+
+	svc := service.NewIssueService(issues, service.WithAttachments(attachments, blobs))
+	if err := svc.DeleteIssue(context.Background(), 1); !errors.Is(err, model.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if len(blobs.blobs) != 1 {
+		t.Fatal("expected the blob to be kept")
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type FieldService struct {
+	repo       FieldRepository
+	authorizer Authorizer
+}
+
+// FieldOption configures optional dependencies of FieldService.
+type FieldOption func(*FieldService)
+
+// WithFieldAuthorizer requires model.PermissionManageFields to create and delete fields.
+// Without it every caller may do so.
+func WithFieldAuthorizer(authorizer Authorizer) FieldOption {
+	return func(s *FieldService) {
+		s.authorizer = authorizer
+	}
+}
+
+func NewFieldService(repo FieldRepository, opts ...FieldOption) *FieldService {
+	s := &FieldService{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *FieldService) CreateField(ctx context.Context, field *model.FieldDefinition) (int, error) {
+	if err := s.authorize(ctx); err != nil {
+		return 0, err
+	}
+
+	if !fieldNamePattern.MatchString(field.Name) {
+		return 0, fmt.Errorf("%w: field name must match %s", model.ErrInvalidInput, fieldNamePattern)
+	}
+
+	switch field.Type {
+	case model.FieldTypeString, model.FieldTypeNumber, model.FieldTypeDate, model.FieldTypeUser:
+		if len(field.Options) > 0 {
+			return 0, fmt.Errorf("%w: options are only allowed for enum fields", model.ErrInvalidInput)
+		}
+	case model.FieldTypeEnum:
+		if len(field.Options) == 0 {
+			return 0, fmt.Errorf("%w: enum field requires options", model.ErrInvalidInput)
+		}
+	default:
+		return 0, fmt.Errorf("%w: unknown field type %q", model.ErrInvalidInput, field.Type)
+	}
+
+	return s.repo.CreateField(ctx, field)
+}
+
+func (s *FieldService) GetFieldByID(ctx context.Context, id int) (*model.FieldDefinition, error) {
+	return s.repo.GetFieldByID(ctx, id)
+}
+
+func (s *FieldService) DeleteField(ctx context.Context, id int) error {
+	if err := s.authorize(ctx); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteField(ctx, id)
+}
+
+func (s *FieldService) ListFields(ctx context.Context) ([]*model.FieldDefinition, error) {
+	return s.repo.ListFields(ctx)
+}
+
+func (s *FieldService) authorize(ctx context.Context) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	return s.authorizer.Authorize(ctx, model.PermissionManageFields)
+}
+
+// validateCustomFields checks issue custom field values against their definitions
+// and adds every rejected one to v as custom_fields.<name>.
+func validateCustomFields(defs []*model.FieldDefinition, values map[string]any, v *model.ValidationError) {
+	byName := make(map[string]*model.FieldDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		field := "custom_fields." + name
+		def, ok := byName[name]
+		if !ok {
+			v.Add(field, model.CodeUnknownField, "is not a defined custom field")
+			continue
+		}
+		if code, msg := validateFieldValue(def, values[name]); code != "" {
+			v.Add(field, code, msg)
+		}
+	}
+
+	for _, def := range defs {
+		if _, ok := values[def.Name]; def.Required && !ok {
+			v.Add("custom_fields."+def.Name, model.CodeRequired, "is required")
+		}
+	}
+}
+
+// validateFieldValue returns the code and message of why value is rejected for
+// the field, or an empty code when it is valid.
+func validateFieldValue(def *model.FieldDefinition, value any) (code, msg string) {
+	switch def.Type {
+	case model.FieldTypeNumber:
+		if _, ok := value.(float64); ok {
+			return "", ""
+		}
+		return model.CodeInvalidType, "must be a number"
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return model.CodeInvalidType, "must be a string"
+	}
+
+	switch def.Type {
+	case model.FieldTypeEnum:
+		if !slices.Contains(def.Options, str) {
+			return model.CodeInvalidValue, "must be one of " + strings.Join(def.Options, ", ")
+		}
+	case model.FieldTypeDate:
+		if _, err := time.Parse(model.DateLayout, str); err != nil {
+			return model.CodeInvalidValue, "must be a date in " + model.DateLayout + " format"
+		}
+	case model.FieldTypeUser:
+		if strings.TrimSpace(str) == "" {
+			return model.CodeRequired, "must name a user"
+		}
+	}
+
+	return "", ""
+}
+
+// parseFieldFilter converts raw filter values coming from the query string
+// into values of the custom field's type, so they can be compared with the stored ones.
+func parseFieldFilter(defs []*model.FieldDefinition, raw map[string]any) (map[string]any, error) {
+	byName := make(map[string]*model.FieldDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	parsed := make(map[string]any, len(raw))
+	for name, value := range raw {
+		def, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown custom field %q", model.ErrInvalidInput, name)
+		}
+
+		if str, ok := value.(string); ok && def.Type == model.FieldTypeNumber {
+			number, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: custom field %q must be a number", model.ErrInvalidInput, name)
+			}
+			value = number
+		}
+
+		if code, msg := validateFieldValue(def, value); code != "" {
+			return nil, fmt.Errorf("%w: custom field %q %s", model.ErrInvalidInput, name, msg)
+		}
+		parsed[name] = value
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,209 @@
+package service_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"context"
+	"errors"
+	"testing"
+)
+
+type MockFieldRepo struct {
+	CreateFunc  func(ctx context.Context, field *model.FieldDefinition) (int, error)
+	GetByIDFunc func(ctx context.Context, id int) (*model.FieldDefinition, error)
+	DeleteFunc  func(ctx context.Context, id int) error
+	ListFunc    func(ctx context.Context) ([]*model.FieldDefinition, error)
+}
+
+func (m *MockFieldRepo) CreateField(ctx context.Context, field *model.FieldDefinition) (int, error) {
+	return m.CreateFunc(ctx, field)
+}
+
+func (m *MockFieldRepo) GetFieldByID(ctx context.Context, id int) (*model.FieldDefinition, error) {
+	return m.GetByIDFunc(ctx, id)
+}
+
+func (m *MockFieldRepo) DeleteField(ctx context.Context, id int) error {
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *MockFieldRepo) ListFields(ctx context.Context) ([]*model.FieldDefinition, error) {
+	return m.ListFunc(ctx)
+}
+
+func testFieldRepo() *MockFieldRepo {
+	return &MockFieldRepo{
+		ListFunc: func(ctx context.Context) ([]*model.FieldDefinition, error) {
+			return []*model.FieldDefinition{
+				{ID: 1, Name: "customer", Type: model.FieldTypeString, Required: true},
+				{ID: 2, Name: "environment", Type: model.FieldTypeEnum, Options: []string{"staging", "production"}},
+				{ID: 3, Name: "estimate", Type: model.FieldTypeNumber},
+				{ID: 4, Name: "due", Type: model.FieldTypeDate},
+			}, nil
+		},
+	}
+}
+
+func TestCreateField_InvalidType(t *testing.T) {
+	called := false
+	mockRepo := &MockFieldRepo{
+		CreateFunc: func(ctx context.Context, field *model.FieldDefinition) (int, error) {
+			called = true
+			return 1, nil
+		},
+	}
+
+	service := service.NewFieldService(mockRepo)
+
+	_, err := service.CreateField(context.Background(), &model.FieldDefinition{Name: "customer", Type: "color"})
+	if !errors.Is(err, model.ErrInvalidInput) {
+		t.Fatalf("expected invalid input error, got %v", err)
+	}
+
+	if called {
+		t.Fatal("expected CreateField not to be called")
+	}
+}
+
+func TestCreateField_EnumRequiresOptions(t *testing.T) {
+	service := service.NewFieldService(&MockFieldRepo{})
+
+	_, err := service.CreateField(context.Background(), &model.FieldDefinition{Name: "environment", Type: model.FieldTypeEnum})
+	if !errors.Is(err, model.ErrInvalidInput) {
+		t.Fatalf("expected invalid input error, got %v", err)
+	}
+}
+
+func TestFieldService_RequiresAdmin(t *testing.T) {
+	mockRepo := &MockFieldRepo{
+		CreateFunc: func(ctx context.Context, field *model.FieldDefinition) (int, error) { return 1, nil },
+		DeleteFunc: func(ctx context.Context, id int) error { return nil },
+	}
+	members := newMemberService(t, map[string]string{"dave": model.RoleDeveloper}, "root")
+	service := service.NewFieldService(mockRepo, service.WithFieldAuthorizer(members))
+	field := &model.FieldDefinition{Name: "customer", Type: model.FieldTypeString}
+
+	for _, user := range []string{"nobody", "dave"} {
+		ctx := auth.WithUser(context.Background(), user)
+		if _, err := service.CreateField(ctx, field); !errors.Is(err, model.ErrForbidden) {
+			t.Fatalf("%s: expected CreateField to be forbidden, got %v", user, err)
+		}
+		if err := service.DeleteField(ctx, 1); !errors.Is(err, model.ErrForbidden) {
+			t.Fatalf("%s: expected DeleteField to be forbidden, got %v", user, err)
+		}
+	}
+
+	ctx := auth.WithUser(context.Background(), "root")
+	if _, err := service.CreateField(ctx, field); err != nil {
+		t.Fatalf("expected an admin to create fields, got %v", err)
+	}
+	if err := service.DeleteField(ctx, 1); err != nil {
+		t.Fatalf("expected an admin to delete fields, got %v", err)
+	}
+}
+
+func TestCreateIssue_CustomFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  map[string]any
+		wantErr bool
+	}{
+		{"valid", map[string]any{"customer": "acme", "environment": "staging", "estimate": 3.0, "due": "2026-01-31"}, false},
+		{"missing required", map[string]any{"environment": "staging"}, true},
+		{"unknown field", map[string]any{"customer": "acme", "color": "red"}, true},
+		{"enum value not allowed", map[string]any{"customer": "acme", "environment": "dev"}, true},
+		{"number as string", map[string]any{"customer": "acme", "estimate": "3"}, true},
+		{"invalid date", map[string]any{"customer": "acme", "due": "31.01.2026"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			mockRepo := &MockRepo{
+				CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+					called = true
+					return 1, nil
+				},
+			}
+
+			service := service.NewIssueService(mockRepo, service.WithFieldRepository(testFieldRepo()))
+
+			_, err := service.CreateIssue(context.Background(), &model.Issue{Title: "Test", CustomFields: tt.fields})
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrInvalidInput) {
+					t.Fatalf("expected invalid input error, got %v", err)
+				}
+				if called {
+					t.Fatal("expected CreateIssue not to be called")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !called {
+				t.Fatal("expected CreateIssue to be called")
+			}
+		})
+	}
+}
+
+func TestCreateIssue_ReportsAllFieldErrors(t *testing.T) {
+	service := service.NewIssueService(&MockRepo{}, service.WithFieldRepository(testFieldRepo()))
+
+	_, err := service.CreateIssue(context.Background(), &model.Issue{
+		CustomFields: map[string]any{"environment": "dev", "estimate": "3", "color": "red"},
+	})
+
+	var v *model.ValidationError
+	if !errors.As(err, &v) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	want := []model.FieldError{
+		{Field: "title", Code: model.CodeRequired},
+		{Field: "custom_fields.color", Code: model.CodeUnknownField},
+		{Field: "custom_fields.environment", Code: model.CodeInvalidValue},
+		{Field: "custom_fields.estimate", Code: model.CodeInvalidType},
+		{Field: "custom_fields.customer", Code: model.CodeRequired},
+	}
+	if len(v.Errors) != len(want) {
+		t.Fatalf("expected %d field errors, got %+v", len(want), v.Errors)
+	}
+	for i, fe := range v.Errors {
+		if fe.Field != want[i].Field || fe.Code != want[i].Code {
+			t.Fatalf("error %d: expected %s %s, got %+v", i, want[i].Field, want[i].Code, fe)
+		}
+	}
+}
+
+func TestListIssues_CustomFieldFilter(t *testing.T) {
+	var got model.IssueFilter
+	mockRepo := &MockRepo{
+		ListFunc: func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
+			got = filter
+			return nil, nil
+		},
+	}
+
+	service := service.NewIssueService(mockRepo, service.WithFieldRepository(testFieldRepo()))
+
+	filter := model.IssueFilter{CustomFields: map[string]any{"customer": "acme", "estimate": "2.5"}}
+	if _, err := service.ListIssues(context.Background(), filter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got.CustomFields["customer"] != "acme" {
+		t.Fatalf("expected customer filter to be passed, got %v", got.CustomFields)
+	}
+
+	if got.CustomFields["estimate"] != 2.5 {
+		t.Fatalf("expected estimate to be parsed as number, got %#v", got.CustomFields["estimate"])
+	}
+
+	filter = model.IssueFilter{CustomFields: map[string]any{"color": "red"}}
+	if _, err := service.ListIssues(context.Background(), filter); !errors.Is(err, model.ErrInvalidInput) {
+		t.Fatalf("expected invalid input error, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// rolePermissions lists what each role may do in addition to reading.
+// Reporters, e.g. contractors, may file issues and edit their own ones,
+// but not close or delete them.
+var rolePermissions = map[string][]string{
+	model.RoleViewer: {},
+	model.RoleReporter: {
+		model.PermissionCreateIssue,
+		model.PermissionEditOwnIssue,
+	},
+	model.RoleDeveloper: {
+		model.PermissionCreateIssue,
+		model.PermissionEditOwnIssue,
+		model.PermissionEditIssue,
+		model.PermissionCloseIssue,
+	},
+	model.RoleMaintainer: {
+		model.PermissionCreateIssue,
+		model.PermissionEditOwnIssue,
+		model.PermissionEditIssue,
+		model.PermissionCloseIssue,
+		model.PermissionDeleteIssue,
+	},
+	model.RoleAdmin: {
+		model.PermissionCreateIssue,
+		model.PermissionEditOwnIssue,
+		model.PermissionEditIssue,
+		model.PermissionCloseIssue,
+		model.PermissionDeleteIssue,
+		model.PermissionManageMembers,
+		model.PermissionManageFields,
+		model.PermissionManageWebhooks,
+	},
+}
+
+// MemberService manages the roles of users and checks their permissions.
+type MemberService struct {
+	repo        MemberRepository
+	defaultRole string
+	admins      []string // tenant:user
+}
+
+// NewMemberService creates the service. Users without a membership get defaultRole,
+// the users listed in admins always have the admin role so that the first memberships can be set up.
+// Admins are entries of the form tenant:user and only admins of that tenant; a plain user
+// is an admin of tenant.Default.
+func NewMemberService(repo MemberRepository, defaultRole string, admins []string) (*MemberService, error) {
+	if !slices.Contains(model.Roles, defaultRole) {
+		return nil, fmt.Errorf("unknown default role %q", defaultRole)
+	}
+
+	scoped := make([]string, 0, len(admins))
+	for _, admin := range admins {
+		tenantID, user, ok := strings.Cut(admin, ":")
+		if !ok {
+			tenantID, user = tenant.Default, admin
+		}
+		if tenantID == "" || user == "" {
+			return nil, fmt.Errorf("invalid admin %q, expected user or tenant:user", admin)
+		}
+		scoped = append(scoped, tenantID+":"+user)
+	}
+
+	return &MemberService{repo: repo, defaultRole: defaultRole, admins: scoped}, nil
+}
+
+// Role returns the role of the calling user.
+func (s *MemberService) Role(ctx context.Context) (string, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return "", model.ErrUnauthorized
+	}
+
+	if slices.Contains(s.admins, tenant.ID(ctx)+":"+user) {
+		return model.RoleAdmin, nil
+	}
+
+	member, err := s.repo.GetMember(ctx, user)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return s.defaultRole, nil
+		}
+		return "", err
+	}
+
+	return member.Role, nil
+}
+
+// Authorize returns model.ErrForbidden unless the role of the calling user grants the permission.
+func (s *MemberService) Authorize(ctx context.Context, permission string) error {
+	role, err := s.Role(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(rolePermissions[role], permission) {
+		return fmt.Errorf("%w: role %s lacks %s", model.ErrForbidden, role, permission)
+	}
+
+	return nil
+}
+
+// SetMember assigns a role to a user.
+func (s *MemberService) SetMember(ctx context.Context, member *model.Member) error {
+	if err := s.Authorize(ctx, model.PermissionManageMembers); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(member.User) == "" {
+		return fmt.Errorf("%w: user is required", model.ErrInvalidInput)
+	}
+	if !slices.Contains(model.Roles, member.Role) {
+		return fmt.Errorf("%w: unknown role %q", model.ErrInvalidInput, member.Role)
+	}
+
+	return s.repo.SetMember(ctx, member)
+}
+
+// RemoveMember removes the membership, the user falls back to the default role.
+func (s *MemberService) RemoveMember(ctx context.Context, user string) error {
+	if err := s.Authorize(ctx, model.PermissionManageMembers); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteMember(ctx, user)
+}
+
+func (s *MemberService) ListMembers(ctx context.Context) ([]*model.Member, error) {
+	if err := s.Authorize(ctx, model.PermissionManageMembers); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListMembers(ctx)
+}
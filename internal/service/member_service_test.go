@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"errors"
+	"testing"
+)
+
+type MockMemberRepo struct {
+	SetFunc    func(ctx context.Context, member *model.Member) error
+	GetFunc    func(ctx context.Context, user string) (*model.Member, error)
+	DeleteFunc func(ctx context.Context, user string) error
+	ListFunc   func(ctx context.Context) ([]*model.Member, error)
+}
+
+func (m *MockMemberRepo) SetMember(ctx context.Context, member *model.Member) error {
+	return m.SetFunc(ctx, member)
+}
+
+func (m *MockMemberRepo) GetMember(ctx context.Context, user string) (*model.Member, error) {
+	return m.GetFunc(ctx, user)
+}
+
+func (m *MockMemberRepo) DeleteMember(ctx context.Context, user string) error {
+	return m.DeleteFunc(ctx, user)
+}
+
+func (m *MockMemberRepo) ListMembers(ctx context.Context) ([]*model.Member, error) {
+	return m.ListFunc(ctx)
+}
+
+// rolesRepo returns a member repository with the given roles by user.
+func rolesRepo(roles map[string]string) *MockMemberRepo {
+	return &MockMemberRepo{
+		GetFunc: func(ctx context.Context, user string) (*model.Member, error) {
+			role, ok := roles[user]
+			if !ok {
+				return nil, model.ErrNotFound
+			}
+			return &model.Member{User: user, Role: role}, nil
+		},
+		SetFunc: func(ctx context.Context, member *model.Member) error { return nil },
+	}
+}
+
+func newMemberService(t *testing.T, roles map[string]string, admins ...string) *service.MemberService {
+	t.Helper()
+
+	s, err := service.NewMemberService(rolesRepo(roles), model.RoleViewer, admins)
+	if err != nil {
+		t.Fatalf("cannot create member service: %v", err)
+	}
+	return s
+}
+
+func TestAuthorize(t *testing.T) {
+	members := newMemberService(t, map[string]string{
+		"carl": model.RoleReporter,
+		"dave": model.RoleDeveloper,
+		"mia":  model.RoleMaintainer,
+	}, "root")
+
+	tests := []struct {
+		user       string
+		permission string
+		allowed    bool
+	}{
+		{"carl", model.PermissionCreateIssue, true},
+		{"carl", model.PermissionEditOwnIssue, true},
+		{"carl", model.PermissionCloseIssue, false},
+		{"carl", model.PermissionDeleteIssue, false},
+		{"dave", model.PermissionCloseIssue, true},
+		{"dave", model.PermissionDeleteIssue, false},
+		{"mia", model.PermissionDeleteIssue, true},
+		{"mia", model.PermissionManageMembers, false},
+		{"root", model.PermissionManageMembers, true},
+		{"mia", model.PermissionManageFields, false},
+		{"mia", model.PermissionManageWebhooks, false},
+		{"root", model.PermissionManageFields, true},
+		{"root", model.PermissionManageWebhooks, true},
+		{"nobody", model.PermissionCreateIssue, false}, // default role viewer
+	}
+
+	for _, tt := range tests {
+		ctx := auth.WithUser(context.Background(), tt.user)
+		err := members.Authorize(ctx, tt.permission)
+		if tt.allowed && err != nil {
+			t.Fatalf("%s %s: expected no error, got %v", tt.user, tt.permission, err)
+		}
+		if !tt.allowed && !errors.Is(err, model.ErrForbidden) {
+			t.Fatalf("%s %s: expected ErrForbidden, got %v", tt.user, tt.permission, err)
+		}
+	}
+
+	if err := members.Authorize(context.Background(), model.PermissionCreateIssue); !errors.Is(err, model.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized for anonymous caller, got %v", err)
+	}
+}
+
+func TestRole_AdminsPerTenant(t *testing.T) {
+	members := newMemberService(t, nil, "root", "finance:fiona")
+
+	tests := []struct {
+		user, tenantID string
+		want           string
+	}{
+		{"root", tenant.Default, model.RoleAdmin},
+		{"root", "finance", model.RoleViewer},
+		{"fiona", "finance", model.RoleAdmin},
+		{"fiona", tenant.Default, model.RoleViewer},
+		{"fiona", "sales", model.RoleViewer},
+	}
+
+	for _, tt := range tests {
+		ctx := tenant.With(auth.WithUser(context.Background(), tt.user), tt.tenantID)
+		role, err := members.Role(ctx)
+		if err != nil || role != tt.want {
+			t.Fatalf("%s in %s: expected %s, got %q %v", tt.user, tt.tenantID, tt.want, role, err)
+		}
+	}
+}
+
+func TestNewMemberService_InvalidAdmin(t *testing.T) {
+	for _, admin := range []string{"finance:", ":fiona", ""} {
+		if _, err := service.NewMemberService(rolesRepo(nil), model.RoleViewer, []string{admin}); err == nil {
+			t.Fatalf("expected an error for admin %q", admin)
+		}
+	}
+}
+
+func TestNewMemberService_UnknownDefaultRole(t *testing.T) {
+	if _, err := service.NewMemberService(rolesRepo(nil), "contractor", nil); err == nil {
+		t.Fatal("expected error for unknown default role")
+	}
+}
+
+func TestSetMember(t *testing.T) {
+	members := newMemberService(t, map[string]string{"mia": model.RoleMaintainer}, "root")
+
+	ctx := auth.WithUser(context.Background(), "mia")
+	if err := members.SetMember(ctx, &model.Member{User: "carl", Role: model.RoleReporter}); !errors.Is(err, model.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for maintainer, got %v", err)
+	}
+
+	ctx = auth.WithUser(context.Background(), "root")
+	if err := members.SetMember(ctx, &model.Member{User: "carl", Role: "contractor"}); !errors.Is(err, model.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for unknown role, got %v", err)
+	}
+	if err := members.SetMember(ctx, &model.Member{User: "carl", Role: model.RoleReporter}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestIssueService_ReporterCannotCloseOrDelete(t *testing.T) {
+	members := newMemberService(t, map[string]string{"carl": model.RoleReporter})
+	mockRepo := &MockRepo{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) { return 1, nil },
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			return &model.Issue{ID: id, Title: "Test", Status: "open", Reporter: "carl"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, issue *model.Issue) error { return nil },
+		DeleteFunc: func(ctx context.Context, id int) error { return nil },
+	}
+
+	issues := service.NewIssueService(mockRepo, service.WithAuthorizer(members))
+	ctx := auth.WithUser(context.Background(), "carl")
+
+	if _, err := issues.CreateIssue(ctx, &model.Issue{Title: "Crash on login"}); err != nil {
+		t.Fatalf("expected reporter to file issues, got %v", err)
+	}
+	if err := issues.UpdateIssue(ctx, &model.Issue{ID: 1, Title: "Crash on login page", Status: "open"}); err != nil {
+		t.Fatalf("expected reporter to edit own issue, got %v", err)
+	}
+	if err := issues.UpdateIssue(ctx, &model.Issue{ID: 1, Title: "Test", Status: "done"}); !errors.Is(err, model.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden when closing, got %v", err)
+	}
+	if err := issues.DeleteIssue(ctx, 1); !errors.Is(err, model.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden when deleting, got %v", err)
+	}
+
+	other := auth.WithUser(context.Background(), "eve")
+	if err := issues.UpdateIssue(other, &model.Issue{ID: 1, Title: "Test", Status: "open"}); !errors.Is(err, model.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden when editing foreign issue, got %v", err)
+	}
+}
+
+func TestListMembers_RequiresAdmin(t *testing.T) {
+	repo := rolesRepo(map[string]string{"dave": model.RoleDeveloper})
+	repo.ListFunc = func(ctx context.Context) ([]*model.Member, error) {
+		return []*model.Member{{User: "dave", Role: model.RoleDeveloper}}, nil
+	}
+	members, err := service.NewMemberService(repo, model.RoleViewer, []string{"root"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, user := range []string{"nobody", "dave"} {
+		if _, err := members.ListMembers(auth.WithUser(context.Background(), user)); !errors.Is(err, model.ErrForbidden) {
+			t.Fatalf("%s: expected ErrForbidden, got %v", user, err)
+		}
+	}
+
+	list, err := members.ListMembers(auth.WithUser(context.Background(), "root"))
+	if err != nil || len(list) != 1 {
+		t.Fatalf("expected the members for an admin, got %v %v", list, err)
+	}
+}
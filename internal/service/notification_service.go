@@ -0,0 +1,71 @@
+package service
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+)
+
+type NotificationService struct {
+	repo   NotificationRepository
+	issues IssueRepository
+}
+
+func NewNotificationService(repo NotificationRepository, issues IssueRepository) *NotificationService {
+	return &NotificationService{repo: repo, issues: issues}
+}
+
+// Subscribe subscribes an email address to changes of the issue.
+func (s *NotificationService) Subscribe(ctx context.Context, subscription *model.EmailSubscription) (int, error) {
+	address, err := mail.ParseAddress(subscription.Email)
+	if err != nil || address.Address != subscription.Email {
+		return 0, fmt.Errorf("%w: invalid email address", model.ErrInvalidInput)
+	}
+
+	if _, err := s.issues.GetIssueByID(ctx, subscription.IssueID); err != nil {
+		return 0, err
+	}
+
+	return s.repo.CreateSubscription(ctx, subscription)
+}
+
+func (s *NotificationService) Unsubscribe(ctx context.Context, issueID, id int) error {
+	return s.repo.DeleteSubscription(ctx, issueID, id)
+}
+
+func (s *NotificationService) ListSubscriptions(ctx context.Context, issueID int) ([]*model.EmailSubscription, error) {
+	return s.repo.ListSubscriptions(ctx, issueID)
+}
+
+// ListSubscriptionsForIssues returns the subscriptions of several issues at once, ordered by issue.
+func (s *NotificationService) ListSubscriptionsForIssues(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error) {
+	return s.repo.ListSubscriptionsForIssues(ctx, issueIDs)
+}
+
+// Publish queues an email for every subscriber of the changed issue.
+// The emails are sent by mail.Worker.
+func (s *NotificationService) Publish(ctx context.Context, event model.IssueEvent) error {
+	switch event.Type {
+	case model.EventIssueCreated, model.EventIssueUpdated, model.EventIssueDeleted:
+	default:
+		// status changes are already part of the update email
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.EnqueueNotifications(ctx, event.IssueID, event.Type, payload); err != nil {
+		return err
+	}
+
+	if event.Type == model.EventIssueDeleted {
+		return s.repo.DeleteIssueSubscriptions(ctx, event.IssueID)
+	}
+
+	return nil
+}
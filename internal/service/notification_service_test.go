@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"context"
+	"errors"
+	"testing"
+)
+
+type MockNotificationRepo struct {
+	CreateFunc         func(ctx context.Context, subscription *model.EmailSubscription) (int, error)
+	DeleteFunc         func(ctx context.Context, issueID, id int) error
+	DeleteForIssueFunc func(ctx context.Context, issueID int) error
+	ListFunc           func(ctx context.Context, issueID int) ([]*model.EmailSubscription, error)
+	BatchFunc          func(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error)
+	EnqueueFunc        func(ctx context.Context, issueID int, event string, payload []byte) error
+}
+
+func (m *MockNotificationRepo) CreateSubscription(ctx context.Context, subscription *model.EmailSubscription) (int, error) {
+	return m.CreateFunc(ctx, subscription)
+}
+
+func (m *MockNotificationRepo) DeleteSubscription(ctx context.Context, issueID, id int) error {
+	return m.DeleteFunc(ctx, issueID, id)
+}
+
+func (m *MockNotificationRepo) DeleteIssueSubscriptions(ctx context.Context, issueID int) error {
+	return m.DeleteForIssueFunc(ctx, issueID)
+}
+
+func (m *MockNotificationRepo) ListSubscriptions(ctx context.Context, issueID int) ([]*model.EmailSubscription, error) {
+	return m.ListFunc(ctx, issueID)
+}
+
+func (m *MockNotificationRepo) ListSubscriptionsForIssues(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error) {
+	return m.BatchFunc(ctx, issueIDs)
+}
+
+func (m *MockNotificationRepo) EnqueueNotifications(ctx context.Context, issueID int, event string, payload []byte) error {
+	return m.EnqueueFunc(ctx, issueID, event, payload)
+}
+
+func TestSubscribe_InvalidEmail(t *testing.T) {
+	service := service.NewNotificationService(&MockNotificationRepo{}, existingIssueRepo())
+
+	for _, email := range []string{"", "alice", "Alice <alice@example.com>"} {
+		_, err := service.Subscribe(context.Background(), &model.EmailSubscription{IssueID: 1, Email: email})
+		if !errors.Is(err, model.ErrInvalidInput) {
+			t.Fatalf("expected invalid input error for %q, got %v", email, err)
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	called := false
+	mockRepo := &MockNotificationRepo{
+		CreateFunc: func(ctx context.Context, subscription *model.EmailSubscription) (int, error) {
+			called = true
+			return 1, nil
+		},
+	}
+
+	service := service.NewNotificationService(mockRepo, existingIssueRepo())
+
+	_, err := service.Subscribe(context.Background(), &model.EmailSubscription{IssueID: 1, Email: "alice@example.com", Digest: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !called {
+		t.Fatal("expected CreateSubscription to be called")
+	}
+}
+
+func TestNotificationPublish(t *testing.T) {
+	var enqueued []string
+	removed := false
+	mockRepo := &MockNotificationRepo{
+		EnqueueFunc: func(ctx context.Context, issueID int, event string, payload []byte) error {
+			enqueued = append(enqueued, event)
+			return nil
+		},
+		DeleteForIssueFunc: func(ctx context.Context, issueID int) error {
+			removed = true
+			return nil
+		},
+	}
+
+	service := service.NewNotificationService(mockRepo, existingIssueRepo())
+
+	for _, eventType := range []string{model.EventIssueUpdated, model.EventIssueStatusChanged, model.EventIssueDeleted} {
+		if err := service.Publish(context.Background(), model.IssueEvent{Type: eventType, IssueID: 1}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if len(enqueued) != 2 || enqueued[0] != model.EventIssueUpdated || enqueued[1] != model.EventIssueDeleted {
+		t.Fatalf("expected update and delete notifications only, got %v", enqueued)
+	}
+
+	if !removed {
+		t.Fatal("expected subscriptions of the deleted issue to be removed")
+	}
+}
@@ -3,11 +3,70 @@ package service
 import (
     "context"
 	"errors"
+	"time"
+    "Go-IssueTracker-API/internal/auth"
+    "Go-IssueTracker-API/internal/logging"
     "Go-IssueTracker-API/internal/model"
+    "Go-IssueTracker-API/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("Go-IssueTracker-API/internal/service")
+
 type IssueService struct {
-    repo IssueRepository
+    repo     IssueRepository
+    fields   FieldRepository
+    watchers WatcherRepository
+    attachments AttachmentRepository
+    blobs       BlobStore
+
+    publishers []EventPublisher
+    authorizer Authorizer
+}
+
+// Option configures optional dependencies of IssueService.
+type Option func(*IssueService)
+
+// WithFieldRepository enables custom fields: their values are validated
+// against the definitions stored in the given repository.
+func WithFieldRepository(fields FieldRepository) Option {
+	return func(s *IssueService) {
+		s.fields = fields
+	}
+}
+
+// WithWatcherRepository makes reporters and assignees watch their issues automatically.
+func WithWatcherRepository(watchers WatcherRepository) Option {
+	return func(s *IssueService) {
+		s.watchers = watchers
+	}
+}
+
+// WithAttachments removes the stored contents of attachments when their issue is deleted.
+func WithAttachments(attachments AttachmentRepository, blobs BlobStore) Option {
+	return func(s *IssueService) {
+		s.attachments = attachments
+		s.blobs = blobs
+	}
+}
+
+// WithEventPublisher registers a publisher notified about every created, updated and deleted issue.
+// The option can be given several times.
+func WithEventPublisher(publisher EventPublisher) Option {
+	return func(s *IssueService) {
+		s.publishers = append(s.publishers, publisher)
+	}
+}
+
+// WithAuthorizer checks the permissions of the calling user before issues are created,
+// edited, closed or deleted. Without it every caller may do everything.
+func WithAuthorizer(authorizer Authorizer) Option {
+	return func(s *IssueService) {
+		s.authorizer = authorizer
+	}
 }
 
 /*
@@ -15,41 +74,262 @@ type IssueService struct {
 	2.GetIssueByID(ctx context.Context, id int) (*model.Issue, error)
 	3.UpdateIssue(ctx context.Context, issue *model.Issue) error
 	4.DeleteIssue(ctx context.Context, id int) error
-	5.ListIssues(ctx context.Context) ([]*model.Issue, error)
+	5.ListIssues(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error)
 */
 
-func NewIssueService(repo IssueRepository) *IssueService {
-    return &IssueService{repo: repo}
+func NewIssueService(repo IssueRepository, opts ...Option) *IssueService {
+    s := &IssueService{repo: repo}
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
 }
 
-func (s *IssueService) CreateIssue(ctx context.Context, issue *model.Issue) (int, error) {
-	if issue.Title == "" {
-		return 0, errors.New("title is required")
+func (s *IssueService) CreateIssue(ctx context.Context, issue *model.Issue) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "IssueService.CreateIssue")
+	defer tracing.End(span, &err)
+
+	if err := s.authorize(ctx, model.PermissionCreateIssue); err != nil {
+		return 0, err
+	}
+
+	if err := s.validate(ctx, issue, true); err != nil {
+		return 0, err
 	}
 
 	issue.Status = "open"
+	issue.Reporter, _ = auth.UserFromContext(ctx)
+	issue.UpdatedBy = issue.Reporter
+
+	id, err := s.repo.CreateIssue(ctx, issue)
+	if err != nil {
+		return 0, err
+	}
 
-	return s.repo.CreateIssue(ctx, issue)
+	issue.ID = id
+	span.SetAttributes(attribute.Int("issue.id", id))
+	s.autoWatch(ctx, id, issue.Reporter, issue.Assignee)
+	s.publish(ctx, model.EventIssueCreated, id, issue, nil)
+
+	return id, nil
 }
 
-func (s *IssueService) GetIssueByID(ctx context.Context, id int) (*model.Issue, error) {
+func (s *IssueService) GetIssueByID(ctx context.Context, id int) (_ *model.Issue, err error) {
+	ctx, span := tracer.Start(ctx, "IssueService.GetIssueByID", trace.WithAttributes(attribute.Int("issue.id", id)))
+	defer tracing.End(span, &err)
+
 	return s.repo.GetIssueByID(ctx, id)
 }
 
-func (s *IssueService) UpdateIssue(ctx context.Context, issue *model.Issue) error {
-	if issue.Status != "open" &&
-	   issue.Status != "in_progress" &&
-	   issue.Status != "done" {
-		return errors.New("invalid status")
+func (s *IssueService) UpdateIssue(ctx context.Context, issue *model.Issue) (err error) {
+	ctx, span := tracer.Start(ctx, "IssueService.UpdateIssue", trace.WithAttributes(attribute.Int("issue.id", issue.ID)))
+	defer tracing.End(span, &err)
+
+	if err := s.validate(ctx, issue, false); err != nil {
+		return err
+	}
+
+	issue.UpdatedBy, _ = auth.UserFromContext(ctx)
+
+	// the previous state is only needed to check permissions and to describe the change to publishers
+	var previous *model.Issue
+	if s.authorizer != nil || len(s.publishers) > 0 {
+		previous, err = s.repo.GetIssueByID(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		issue.Reporter = previous.Reporter
+	}
+
+	if err := s.authorizeUpdate(ctx, previous, issue); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateIssue(ctx, issue); err != nil {
+		return err
+	}
+
+	s.autoWatch(ctx, issue.ID, issue.Assignee)
+	s.publish(ctx, model.EventIssueUpdated, issue.ID, issue, previous)
+	if previous != nil && previous.Status != issue.Status {
+		s.publish(ctx, model.EventIssueStatusChanged, issue.ID, issue, previous)
 	}
-	
-	return s.repo.UpdateIssue(ctx, issue)
+
+	return nil
 }
 
-func (s *IssueService) DeleteIssue(ctx context.Context, id int) error {
-	return s.repo.DeleteIssue(ctx, id)
+func (s *IssueService) DeleteIssue(ctx context.Context, id int) (err error) {
+	ctx, span := tracer.Start(ctx, "IssueService.DeleteIssue", trace.WithAttributes(attribute.Int("issue.id", id)))
+	defer tracing.End(span, &err)
+
+	if err := s.authorize(ctx, model.PermissionDeleteIssue); err != nil {
+		return err
+	}
+
+	var previous *model.Issue
+	if len(s.publishers) > 0 {
+		previous, err = s.repo.GetIssueByID(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the rows of the attachments are deleted with the issue, their blobs are not
+	var attachments []*model.Attachment
+	if s.attachments != nil {
+		attachments, err = s.attachments.ListAttachments(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.DeleteIssue(ctx, id); err != nil {
+		return err
+	}
+
+	s.deleteBlobs(ctx, id, attachments)
+	s.publish(ctx, model.EventIssueDeleted, id, nil, previous)
+
+	return nil
 }
 
-func (s *IssueService) ListIssues(ctx context.Context) ([]*model.Issue, error) {
-	return s.repo.ListIssues(ctx)
+func (s *IssueService) ListIssues(ctx context.Context, filter model.IssueFilter) (_ []*model.Issue, err error) {
+	ctx, span := tracer.Start(ctx, "IssueService.ListIssues")
+	defer tracing.End(span, &err)
+
+	if filter, err = s.parseFilter(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListIssues(ctx, filter)
+}
+
+// CountIssues returns the number of issues matching the filter, ignoring its paging.
+func (s *IssueService) CountIssues(ctx context.Context, filter model.IssueFilter) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "IssueService.CountIssues")
+	defer tracing.End(span, &err)
+
+	if filter, err = s.parseFilter(ctx, filter); err != nil {
+		return 0, err
+	}
+
+	return s.repo.CountIssues(ctx, filter)
+}
+
+// parseFilter converts the custom field values of the filter to the types of their definitions.
+func (s *IssueService) parseFilter(ctx context.Context, filter model.IssueFilter) (model.IssueFilter, error) {
+	if len(filter.CustomFields) == 0 {
+		return filter, nil
+	}
+
+	defs, err := s.fieldDefinitions(ctx)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.CustomFields, err = parseFieldFilter(defs, filter.CustomFields)
+	return filter, err
+}
+
+func (s *IssueService) authorize(ctx context.Context, permission string) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	return s.authorizer.Authorize(ctx, permission)
+}
+
+// authorizeUpdate allows editing any issue, or only the caller's own ones,
+// and additionally requires a separate permission to close an issue.
+func (s *IssueService) authorizeUpdate(ctx context.Context, previous, issue *model.Issue) error {
+	if s.authorizer == nil {
+		return nil
+	}
+
+	err := s.authorize(ctx, model.PermissionEditIssue)
+	if errors.Is(err, model.ErrForbidden) {
+		if user, ok := auth.UserFromContext(ctx); ok && user == previous.Reporter {
+			err = s.authorize(ctx, model.PermissionEditOwnIssue)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if issue.Status == "done" && previous.Status != "done" {
+		return s.authorize(ctx, model.PermissionCloseIssue)
+	}
+
+	return nil
+}
+
+// publish notifies all registered publishers about the change.
+// The change is already stored at this point, so publishing errors are only logged.
+func (s *IssueService) publish(ctx context.Context, eventType string, issueID int, issue, previous *model.Issue) {
+	if len(s.publishers) == 0 {
+		return
+	}
+
+	actor, _ := auth.UserFromContext(ctx)
+	event := model.IssueEvent{
+		Type:       eventType,
+		IssueID:    issueID,
+		Issue:      issue,
+		Previous:   previous,
+		Actor:      actor,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	for _, publisher := range s.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			logging.FromContext(ctx).Error("cannot publish issue event", "event", eventType, "issue_id", issueID, "error", err)
+		}
+	}
+}
+
+// deleteBlobs removes the contents of the attachments of a deleted issue.
+// The issue is gone either way, so errors are only logged; the blobs have to be removed by hand.
+func (s *IssueService) deleteBlobs(ctx context.Context, issueID int, attachments []*model.Attachment) {
+	ctx = context.WithoutCancel(ctx)
+	for _, attachment := range attachments {
+		if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+			logging.FromContext(ctx).Error("cannot delete attachment contents", "issue_id", issueID, "attachment_id", attachment.ID, "key", attachment.StorageKey, "error", err)
+		}
+	}
+}
+
+// autoWatch subscribes the given users to the issue.
+// Failing to do so must not fail the change of the issue itself, so errors are only logged.
+func (s *IssueService) autoWatch(ctx context.Context, issueID int, users ...string) {
+	if s.watchers == nil {
+		return
+	}
+
+	for _, user := range users {
+		if user == "" {
+			continue
+		}
+		if err := s.watchers.AddWatcher(ctx, issueID, user); err != nil {
+			logging.FromContext(ctx).Error("cannot subscribe user to issue", "watcher", user, "issue_id", issueID, "error", err)
+		}
+	}
+}
+
+// validate checks the built-in and custom fields of an issue and reports every rejected field at once.
+func (s *IssueService) validate(ctx context.Context, issue *model.Issue, create bool) error {
+	defs, err := s.fieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var v model.ValidationError
+	validateIssue(issue, create, &v)
+	validateCustomFields(defs, issue.CustomFields, &v)
+	return v.Err()
+}
+
+func (s *IssueService) fieldDefinitions(ctx context.Context) ([]*model.FieldDefinition, error) {
+	if s.fields == nil {
+		return nil, nil
+	}
+	return s.fields.ListFields(ctx)
 }
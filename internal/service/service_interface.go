@@ -3,6 +3,7 @@ package service
 import (
 	"Go-IssueTracker-API/internal/model"
 	"context"
+	"io"
 )
 
 type IssueRepository interface {
@@ -10,5 +11,80 @@ type IssueRepository interface {
 	GetIssueByID(ctx context.Context, id int) (*model.Issue, error)
 	UpdateIssue(ctx context.Context, issue *model.Issue) error
 	DeleteIssue(ctx context.Context, id int) error
-	ListIssues(ctx context.Context) ([]*model.Issue, error)
-}
\ No newline at end of file
+	ListIssues(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error)
+	CountIssues(ctx context.Context, filter model.IssueFilter) (int, error)
+}
+
+type FieldRepository interface {
+	CreateField(ctx context.Context, field *model.FieldDefinition) (int, error)
+	GetFieldByID(ctx context.Context, id int) (*model.FieldDefinition, error)
+	DeleteField(ctx context.Context, id int) error
+	ListFields(ctx context.Context) ([]*model.FieldDefinition, error)
+}
+
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *model.Attachment) (int, error)
+	GetAttachment(ctx context.Context, issueID, id int) (*model.Attachment, error)
+	ListAttachments(ctx context.Context, issueID int) ([]*model.Attachment, error)
+	ListAttachmentsForIssues(ctx context.Context, issueIDs []int) ([]*model.Attachment, error)
+}
+
+// BlobStore keeps the contents of attachments, see package storage for implementations.
+// A negative size means the length of r is not known in advance.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type WatcherRepository interface {
+	AddWatcher(ctx context.Context, issueID int, user string) error
+	RemoveWatcher(ctx context.Context, issueID int, user string) error
+	ListWatchedIssues(ctx context.Context, user string) ([]*model.Issue, error)
+}
+
+// EventPublisher is notified about every change made through IssueService.
+type EventPublisher interface {
+	Publish(ctx context.Context, event model.IssueEvent) error
+}
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) (int, error)
+	GetWebhook(ctx context.Context, id int) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, id int) error
+	ListWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	EnqueueDeliveries(ctx context.Context, event string, payload []byte) error
+	ListDeliveries(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error)
+	ListDeliveryAttempts(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error)
+}
+
+type NotificationRepository interface {
+	CreateSubscription(ctx context.Context, subscription *model.EmailSubscription) (int, error)
+	DeleteSubscription(ctx context.Context, issueID, id int) error
+	DeleteIssueSubscriptions(ctx context.Context, issueID int) error
+	ListSubscriptions(ctx context.Context, issueID int) ([]*model.EmailSubscription, error)
+	ListSubscriptionsForIssues(ctx context.Context, issueIDs []int) ([]*model.EmailSubscription, error)
+	EnqueueNotifications(ctx context.Context, issueID int, event string, payload []byte) error
+}
+
+type TokenRepository interface {
+	CreateToken(ctx context.Context, token *model.APIToken) (int, error)
+	GetTokenByHash(ctx context.Context, hash string) (*model.APIToken, error)
+	ListTokens(ctx context.Context, user string) ([]*model.APIToken, error)
+	RevokeToken(ctx context.Context, user string, id int) error
+	TouchToken(ctx context.Context, id int) error
+}
+
+type MemberRepository interface {
+	SetMember(ctx context.Context, member *model.Member) error
+	GetMember(ctx context.Context, user string) (*model.Member, error)
+	DeleteMember(ctx context.Context, user string) error
+	ListMembers(ctx context.Context) ([]*model.Member, error)
+}
+
+// Authorizer decides whether the calling user holds a permission.
+// It returns model.ErrForbidden when not.
+type Authorizer interface {
+	Authorize(ctx context.Context, permission string) error
+}
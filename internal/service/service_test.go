@@ -1,9 +1,11 @@
 package service_test
 
 import (
+	"Go-IssueTracker-API/internal/auth"
 	"Go-IssueTracker-API/internal/model"
 	"Go-IssueTracker-API/internal/service"
 	"context"
+	"strings"
 	"testing"
 	"errors"
 )
@@ -13,7 +15,8 @@ type MockRepo struct {
 	GetByIDFunc    func(ctx context.Context, id int) (*model.Issue, error)
 	UpdateFunc     func(ctx context.Context, issue *model.Issue) error
 	DeleteFunc     func(ctx context.Context, id int) error
-	ListFunc       func(ctx context.Context) ([]*model.Issue, error)
+	ListFunc       func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error)
+	CountFunc      func(ctx context.Context, filter model.IssueFilter) (int, error)
 }
 
 func (m *MockRepo) CreateIssue(ctx context.Context, issue *model.Issue) (int, error) {
@@ -32,8 +35,12 @@ func (m *MockRepo) DeleteIssue(ctx context.Context, id int) error {
 	return m.DeleteFunc(ctx, id)
 }
 
-func (m *MockRepo) ListIssues(ctx context.Context) ([]*model.Issue, error) {
-	return m.ListFunc(ctx)
+func (m *MockRepo) ListIssues(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
+	return m.ListFunc(ctx, filter)
+}
+
+func (m *MockRepo) CountIssues(ctx context.Context, filter model.IssueFilter) (int, error) {
+	return m.CountFunc(ctx, filter)
 }
 
 func TestCreateIssue(t *testing.T) {
@@ -195,7 +202,7 @@ func TestDeleteIssue(t *testing.T) {
 func TestListIssues(t *testing.T) {
 	called := false
 	mockRepo := &MockRepo{
-		ListFunc: func(ctx context.Context) ([]*model.Issue, error) {
+		ListFunc: func(ctx context.Context, filter model.IssueFilter) ([]*model.Issue, error) {
 			called = true
 			return []*model.Issue{
 				{ID: 1, Title: "Test Issue 1"},
@@ -205,7 +212,7 @@ func TestListIssues(t *testing.T) {
 	}
 
 	service := service.NewIssueService(mockRepo)
-	issues, err := service.ListIssues(context.Background())
+	issues, err := service.ListIssues(context.Background(), model.IssueFilter{})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -217,4 +224,85 @@ func TestListIssues(t *testing.T) {
 	if !called {
 		t.Fatal("expected ListIssues to be called")
 	}
-}
\ No newline at end of file
+}
+
+func TestUpdateIssue_RecordsActingUser(t *testing.T) {
+	var updatedBy string
+	mockRepo := &MockRepo{
+		UpdateFunc: func(ctx context.Context, issue *model.Issue) error {
+			updatedBy = issue.UpdatedBy
+			return nil
+		},
+	}
+
+	service := service.NewIssueService(mockRepo)
+
+	ctx := auth.WithUser(context.Background(), "alice")
+	issue := &model.Issue{ID: 1, Title: "Test", Status: "open", UpdatedBy: "mallory"}
+	if err := service.UpdateIssue(ctx, issue); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if updatedBy != "alice" {
+		t.Fatalf("expected updated_by alice, got %q", updatedBy)
+	}
+}
+
+func TestCreateIssue_ValidationErrors(t *testing.T) {
+	mockRepo := &MockRepo{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			t.Fatal("expected CreateIssue not to be called")
+			return 0, nil
+		},
+	}
+
+	service := service.NewIssueService(mockRepo)
+
+	issue := &model.Issue{
+		Title:       strings.Repeat("x", 201),
+		Description: "line one\nline two\x00",
+		Assignee:    "bob smith",
+	}
+	_, err := service.CreateIssue(context.Background(), issue)
+
+	if !errors.Is(err, model.ErrInvalidInput) {
+		t.Fatalf("expected invalid input, got %v", err)
+	}
+
+	var v *model.ValidationError
+	if !errors.As(err, &v) {
+		t.Fatalf("expected a validation error, got %T", err)
+	}
+	want := []model.FieldError{
+		{Field: "title", Code: model.CodeTooLong},
+		{Field: "description", Code: model.CodeInvalidCharacters},
+		{Field: "assignee", Code: model.CodeInvalidCharacters},
+	}
+	if len(v.Errors) != len(want) {
+		t.Fatalf("expected %d errors, got %+v", len(want), v.Errors)
+	}
+	for i, fe := range v.Errors {
+		if fe.Field != want[i].Field || fe.Code != want[i].Code {
+			t.Fatalf("expected %s %s, got %+v", want[i].Field, want[i].Code, fe)
+		}
+	}
+}
+
+func TestCreateIssue_AcceptsMultilineUnicode(t *testing.T) {
+	mockRepo := &MockRepo{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			return 1, nil
+		},
+	}
+
+	service := service.NewIssueService(mockRepo)
+
+	issue := &model.Issue{
+		Title:       "Ошибка входа — 🔑",
+		Description: "Steps:\r\n\t1. open the login page\n\t2. submit",
+		Assignee:    "alice",
+	}
+	if _, err := service.CreateIssue(context.Background(), issue); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package service_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockTokenRepo struct {
+	CreateFunc    func(ctx context.Context, token *model.APIToken) (int, error)
+	GetByHashFunc func(ctx context.Context, hash string) (*model.APIToken, error)
+	ListFunc      func(ctx context.Context, user string) ([]*model.APIToken, error)
+	RevokeFunc    func(ctx context.Context, user string, id int) error
+	TouchFunc     func(ctx context.Context, id int) error
+}
+
+func (m *MockTokenRepo) CreateToken(ctx context.Context, token *model.APIToken) (int, error) {
+	return m.CreateFunc(ctx, token)
+}
+
+func (m *MockTokenRepo) GetTokenByHash(ctx context.Context, hash string) (*model.APIToken, error) {
+	return m.GetByHashFunc(ctx, hash)
+}
+
+func (m *MockTokenRepo) ListTokens(ctx context.Context, user string) ([]*model.APIToken, error) {
+	return m.ListFunc(ctx, user)
+}
+
+func (m *MockTokenRepo) RevokeToken(ctx context.Context, user string, id int) error {
+	return m.RevokeFunc(ctx, user, id)
+}
+
+func (m *MockTokenRepo) TouchToken(ctx context.Context, id int) error {
+	return m.TouchFunc(ctx, id)
+}
+
+// memoryTokenRepo keeps created tokens by hash.
+func memoryTokenRepo() *MockTokenRepo {
+	tokens := map[string]*model.APIToken{}
+	return &MockTokenRepo{
+		CreateFunc: func(ctx context.Context, token *model.APIToken) (int, error) {
+			stored := *token
+			stored.Token = ""
+			stored.ID = len(tokens) + 1
+			tokens[token.Hash] = &stored
+			return stored.ID, nil
+		},
+		GetByHashFunc: func(ctx context.Context, hash string) (*model.APIToken, error) {
+			token, ok := tokens[hash]
+			if !ok {
+				return nil, model.ErrNotFound
+			}
+			return token, nil
+		},
+		TouchFunc: func(ctx context.Context, id int) error { return nil },
+	}
+}
+
+func TestCreateToken(t *testing.T) {
+	repo := memoryTokenRepo()
+	tokenService := service.NewTokenService(repo)
+
+	ctx := tenant.With(auth.WithUser(context.Background(), "ci-bot"), "finance")
+	token := &model.APIToken{Name: "ci", Scopes: []string{model.ScopeIssuesWrite}}
+	if _, err := tokenService.CreateToken(ctx, token); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(token.Token, model.APITokenPrefix) {
+		t.Fatalf("expected token with prefix %q, got %q", model.APITokenPrefix, token.Token)
+	}
+	if token.Hash == "" || strings.Contains(token.Hash, token.Token) {
+		t.Fatalf("expected token to be stored hashed, got %q", token.Hash)
+	}
+
+	got, err := tokenService.AuthenticateToken(context.Background(), token.Token)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.User != "ci-bot" || got.Tenant != "finance" || len(got.Scopes) != 1 || got.Scopes[0] != model.ScopeIssuesWrite {
+		t.Fatalf("unexpected token %+v", got)
+	}
+}
+
+func TestCreateToken_Invalid(t *testing.T) {
+	tokenService := service.NewTokenService(memoryTokenRepo())
+	ctx := auth.WithUser(context.Background(), "alice")
+	past := time.Now().Add(-time.Hour)
+
+	for name, token := range map[string]*model.APIToken{
+		"no name":       {Scopes: []string{model.ScopeIssuesRead}},
+		"no scopes":     {Name: "ci"},
+		"unknown scope": {Name: "ci", Scopes: []string{"issues:admin"}},
+		"expired":       {Name: "ci", Scopes: []string{model.ScopeIssuesRead}, ExpiresAt: &past},
+	} {
+		if _, err := tokenService.CreateToken(ctx, token); !errors.Is(err, model.ErrInvalidInput) {
+			t.Fatalf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+
+	if _, err := tokenService.CreateToken(context.Background(), &model.APIToken{Name: "ci", Scopes: []string{model.ScopeIssuesRead}}); !errors.Is(err, model.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestCreateToken_CannotEscalateScopes(t *testing.T) {
+	tokenService := service.NewTokenService(memoryTokenRepo())
+
+	ctx := auth.WithScopes(auth.WithUser(context.Background(), "ci-bot"), []string{model.ScopeIssuesWrite})
+	_, err := tokenService.CreateToken(ctx, &model.APIToken{Name: "more", Scopes: []string{model.ScopeAdmin}})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestAuthenticateToken_Rejected(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	for name, stored := range map[string]*model.APIToken{
+		"revoked": {User: "alice", RevokedAt: &past, ExpiresAt: &future},
+		"expired": {User: "alice", ExpiresAt: &past},
+		"unknown": nil,
+	} {
+		repo := &MockTokenRepo{
+			GetByHashFunc: func(ctx context.Context, hash string) (*model.APIToken, error) {
+				if stored == nil {
+					return nil, model.ErrNotFound
+				}
+				return stored, nil
+			},
+		}
+
+		_, err := service.NewTokenService(repo).AuthenticateToken(context.Background(), "itk_secret")
+		if !errors.Is(err, model.ErrUnauthorized) {
+			t.Fatalf("%s: expected ErrUnauthorized, got %v", name, err)
+		}
+	}
+}
+
+func TestRevokeToken_OnlyOwnTokens(t *testing.T) {
+	var gotUser string
+	repo := &MockTokenRepo{
+		RevokeFunc: func(ctx context.Context, user string, id int) error {
+			gotUser = user
+			return nil
+		},
+	}
+
+	ctx := auth.WithUser(context.Background(), "alice")
+	if err := service.NewTokenService(repo).RevokeToken(ctx, 3); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotUser != "alice" {
+		t.Fatalf("expected revoke for alice, got %q", gotUser)
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of issue fields, in characters for the title and assignee and in bytes for the description.
+const (
+	MaxTitleLength     = 200
+	MaxDescriptionSize = 64 << 10
+	MaxAssigneeLength  = 100
+)
+
+// validateIssue checks the built-in fields of an issue and adds every rejected one to v.
+// The title is only required when creating; updates keep accepting what they accepted before.
+func validateIssue(issue *model.Issue, create bool, v *model.ValidationError) {
+	switch {
+	case strings.TrimSpace(issue.Title) == "":
+		if create || issue.Title != "" {
+			v.Add("title", model.CodeRequired, "is required")
+		}
+	case utf8.RuneCountInString(issue.Title) > MaxTitleLength:
+		v.Add("title", model.CodeTooLong, fmt.Sprintf("must be at most %d characters", MaxTitleLength))
+	case !validText(issue.Title, false):
+		v.Add("title", model.CodeInvalidCharacters, "must be valid UTF-8 on a single line without control characters")
+	}
+
+	switch {
+	case len(issue.Description) > MaxDescriptionSize:
+		v.Add("description", model.CodeTooLong, fmt.Sprintf("must be at most %d bytes", MaxDescriptionSize))
+	case !validText(issue.Description, true):
+		v.Add("description", model.CodeInvalidCharacters, "must be valid UTF-8 without control characters other than tabs and line breaks")
+	}
+
+	switch {
+	case utf8.RuneCountInString(issue.Assignee) > MaxAssigneeLength:
+		v.Add("assignee", model.CodeTooLong, fmt.Sprintf("must be at most %d characters", MaxAssigneeLength))
+	case strings.IndexFunc(issue.Assignee, unicode.IsSpace) >= 0 || !validText(issue.Assignee, false):
+		v.Add("assignee", model.CodeInvalidCharacters, "must be valid UTF-8 without spaces or control characters")
+	}
+
+	// the status is set by the service on create
+	if !create && !slices.Contains(model.IssueStatuses, issue.Status) {
+		v.Add("status", model.CodeInvalidValue, fmt.Sprintf("must be one of %q", model.IssueStatuses))
+	}
+}
+
+// validText reports whether s is valid UTF-8 without control characters.
+// Multiline text may contain tabs and line breaks.
+func validText(s string, multiline bool) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if multiline && (r == '\n' || r == '\r' || r == '\t') {
+			continue
+		}
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
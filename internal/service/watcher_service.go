@@ -0,0 +1,50 @@
+package service
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"context"
+)
+
+type WatcherService struct {
+	repo   WatcherRepository
+	issues IssueRepository
+}
+
+func NewWatcherService(repo WatcherRepository, issues IssueRepository) *WatcherService {
+	return &WatcherService{repo: repo, issues: issues}
+}
+
+// WatchIssue subscribes the calling user to the issue.
+func (s *WatcherService) WatchIssue(ctx context.Context, issueID int) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return model.ErrUnauthorized
+	}
+
+	if _, err := s.issues.GetIssueByID(ctx, issueID); err != nil {
+		return err
+	}
+
+	return s.repo.AddWatcher(ctx, issueID, user)
+}
+
+// UnwatchIssue unsubscribes the calling user from the issue.
+func (s *WatcherService) UnwatchIssue(ctx context.Context, issueID int) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return model.ErrUnauthorized
+	}
+
+	return s.repo.RemoveWatcher(ctx, issueID, user)
+}
+
+// ListWatchedIssues returns the issues the calling user is watching.
+func (s *WatcherService) ListWatchedIssues(ctx context.Context) ([]*model.Issue, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, model.ErrUnauthorized
+	}
+
+	return s.repo.ListWatchedIssues(ctx, user)
+}
@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+type MockWatcherRepo struct {
+	AddFunc    func(ctx context.Context, issueID int, user string) error
+	RemoveFunc func(ctx context.Context, issueID int, user string) error
+	ListFunc   func(ctx context.Context, user string) ([]*model.Issue, error)
+}
+
+func (m *MockWatcherRepo) AddWatcher(ctx context.Context, issueID int, user string) error {
+	return m.AddFunc(ctx, issueID, user)
+}
+
+func (m *MockWatcherRepo) RemoveWatcher(ctx context.Context, issueID int, user string) error {
+	return m.RemoveFunc(ctx, issueID, user)
+}
+
+func (m *MockWatcherRepo) ListWatchedIssues(ctx context.Context, user string) ([]*model.Issue, error) {
+	return m.ListFunc(ctx, user)
+}
+
+func TestCreateIssue_AutoWatch(t *testing.T) {
+	var reporter string
+	mockRepo := &MockRepo{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			reporter = issue.Reporter
+			return 4, nil
+		},
+	}
+
+	var watchers []string
+	watcherRepo := &MockWatcherRepo{
+		AddFunc: func(ctx context.Context, issueID int, user string) error {
+			if issueID != 4 {
+				t.Fatalf("expected issue 4, got %d", issueID)
+			}
+			watchers = append(watchers, user)
+			return nil
+		},
+	}
+
+	service := service.NewIssueService(mockRepo, service.WithWatcherRepository(watcherRepo))
+
+	ctx := auth.WithUser(context.Background(), "alice")
+	issue := &model.Issue{Title: "Test", Reporter: "mallory", Assignee: "bob"}
+	if _, err := service.CreateIssue(ctx, issue); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if reporter != "alice" {
+		t.Fatalf("expected reporter to be the caller, got %q", reporter)
+	}
+
+	if !slices.Equal(watchers, []string{"alice", "bob"}) {
+		t.Fatalf("expected reporter and assignee to watch, got %v", watchers)
+	}
+}
+
+func TestUpdateIssue_AutoWatchAssignee(t *testing.T) {
+	mockRepo := &MockRepo{
+		UpdateFunc: func(ctx context.Context, issue *model.Issue) error {
+			return nil
+		},
+	}
+
+	var watchers []string
+	watcherRepo := &MockWatcherRepo{
+		AddFunc: func(ctx context.Context, issueID int, user string) error {
+			watchers = append(watchers, user)
+			return nil
+		},
+	}
+
+	service := service.NewIssueService(mockRepo, service.WithWatcherRepository(watcherRepo))
+
+	issue := &model.Issue{ID: 1, Title: "Test", Status: "open", Assignee: "carol"}
+	if err := service.UpdateIssue(context.Background(), issue); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !slices.Equal(watchers, []string{"carol"}) {
+		t.Fatalf("expected assignee to watch, got %v", watchers)
+	}
+}
+
+func TestWatchIssue(t *testing.T) {
+	called := false
+	watcherRepo := &MockWatcherRepo{
+		AddFunc: func(ctx context.Context, issueID int, user string) error {
+			called = true
+			if user != "alice" || issueID != 2 {
+				t.Fatalf("unexpected watcher %s for issue %d", user, issueID)
+			}
+			return nil
+		},
+	}
+
+	service := service.NewWatcherService(watcherRepo, existingIssueRepo())
+
+	err := service.WatchIssue(auth.WithUser(context.Background(), "alice"), 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !called {
+		t.Fatal("expected AddWatcher to be called")
+	}
+}
+
+func TestWatchIssue_Unauthorized(t *testing.T) {
+	service := service.NewWatcherService(&MockWatcherRepo{}, existingIssueRepo())
+
+	err := service.WatchIssue(context.Background(), 2)
+	if !errors.Is(err, model.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+}
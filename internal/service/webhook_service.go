@@ -0,0 +1,169 @@
+package service
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+type WebhookService struct {
+	repo       WebhookRepository
+	authorizer Authorizer
+}
+
+// WebhookOption configures optional dependencies of WebhookService.
+type WebhookOption func(*WebhookService)
+
+// WithWebhookAuthorizer requires model.PermissionManageWebhooks to see and change webhooks
+// and their deliveries. Without it every caller may do so.
+func WithWebhookAuthorizer(authorizer Authorizer) WebhookOption {
+	return func(s *WebhookService) {
+		s.authorizer = authorizer
+	}
+}
+
+func NewWebhookService(repo WebhookRepository, opts ...WebhookOption) *WebhookService {
+	s := &WebhookService{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateWebhook registers a webhook. When no secret is given a random one is generated,
+// it is left in webhook.Secret so it can be shown to the caller once.
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *model.Webhook) (int, error) {
+	if err := s.authorize(ctx); err != nil {
+		return 0, err
+	}
+
+	if err := validateWebhook(webhook); err != nil {
+		return 0, err
+	}
+
+	if webhook.Secret == "" {
+		webhook.Secret = rand.Text()
+	}
+
+	return s.repo.CreateWebhook(ctx, webhook)
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id int) (*model.Webhook, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.repo.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// UpdateWebhook replaces the webhook settings. An empty secret keeps the current one.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	if err := s.authorize(ctx); err != nil {
+		return err
+	}
+
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	if webhook.Secret == "" {
+		current, err := s.repo.GetWebhook(ctx, webhook.ID)
+		if err != nil {
+			return err
+		}
+		webhook.Secret = current.Secret
+	}
+
+	return s.repo.UpdateWebhook(ctx, webhook)
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int) error {
+	if err := s.authorize(ctx); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteWebhook(ctx, id)
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListDeliveries(ctx, webhookID)
+}
+
+func (s *WebhookService) ListDeliveryAttempts(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListDeliveryAttempts(ctx, webhookID, deliveryID)
+}
+
+func (s *WebhookService) authorize(ctx context.Context) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	return s.authorizer.Authorize(ctx, model.PermissionManageWebhooks)
+}
+
+// Publish queues the event for all webhooks subscribed to it.
+// The deliveries are sent by webhook.Worker.
+func (s *WebhookService) Publish(ctx context.Context, event model.IssueEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.EnqueueDeliveries(ctx, event.Type, payload)
+}
+
+func validateWebhook(webhook *model.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: webhook url must be an absolute http(s) URL", model.ErrInvalidInput)
+	}
+
+	for _, event := range webhook.Events {
+		if !slices.Contains(model.EventTypes, event) {
+			return fmt.Errorf("%w: unknown event %q", model.ErrInvalidInput, event)
+		}
+	}
+
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package service_test
+
+import (
+	"Go-IssueTracker-API/internal/auth"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+type MockWebhookRepo struct {
+	CreateFunc       func(ctx context.Context, webhook *model.Webhook) (int, error)
+	GetFunc          func(ctx context.Context, id int) (*model.Webhook, error)
+	UpdateFunc       func(ctx context.Context, webhook *model.Webhook) error
+	DeleteFunc       func(ctx context.Context, id int) error
+	ListFunc         func(ctx context.Context) ([]*model.Webhook, error)
+	EnqueueFunc      func(ctx context.Context, event string, payload []byte) error
+	ListDeliveryFunc func(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error)
+	ListAttemptsFunc func(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error)
+}
+
+func (m *MockWebhookRepo) CreateWebhook(ctx context.Context, webhook *model.Webhook) (int, error) {
+	return m.CreateFunc(ctx, webhook)
+}
+
+func (m *MockWebhookRepo) GetWebhook(ctx context.Context, id int) (*model.Webhook, error) {
+	return m.GetFunc(ctx, id)
+}
+
+func (m *MockWebhookRepo) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	return m.UpdateFunc(ctx, webhook)
+}
+
+func (m *MockWebhookRepo) DeleteWebhook(ctx context.Context, id int) error {
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *MockWebhookRepo) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	return m.ListFunc(ctx)
+}
+
+func (m *MockWebhookRepo) EnqueueDeliveries(ctx context.Context, event string, payload []byte) error {
+	return m.EnqueueFunc(ctx, event, payload)
+}
+
+func (m *MockWebhookRepo) ListDeliveries(ctx context.Context, webhookID int) ([]*model.WebhookDelivery, error) {
+	return m.ListDeliveryFunc(ctx, webhookID)
+}
+
+func (m *MockWebhookRepo) ListDeliveryAttempts(ctx context.Context, webhookID, deliveryID int) ([]*model.DeliveryAttempt, error) {
+	return m.ListAttemptsFunc(ctx, webhookID, deliveryID)
+}
+
+// RecordingPublisher remembers published events.
+type RecordingPublisher struct {
+	events []model.IssueEvent
+}
+
+func (p *RecordingPublisher) Publish(ctx context.Context, event model.IssueEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *RecordingPublisher) types() []string {
+	var types []string
+	for _, event := range p.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestCreateWebhook(t *testing.T) {
+	var saved *model.Webhook
+	mockRepo := &MockWebhookRepo{
+		CreateFunc: func(ctx context.Context, webhook *model.Webhook) (int, error) {
+			saved = webhook
+			return 1, nil
+		},
+	}
+
+	service := service.NewWebhookService(mockRepo)
+
+	webhook := &model.Webhook{URL: "https://ci.example.com/hook", Events: []string{model.EventIssueCreated}}
+	if _, err := service.CreateWebhook(context.Background(), webhook); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if saved == nil || saved.Secret == "" {
+		t.Fatalf("expected a secret to be generated, got %+v", saved)
+	}
+}
+
+func TestCreateWebhook_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook *model.Webhook
+	}{
+		{"relative url", &model.Webhook{URL: "/hook"}},
+		{"unsupported scheme", &model.Webhook{URL: "ftp://example.com/hook"}},
+		{"unknown event", &model.Webhook{URL: "https://example.com/hook", Events: []string{"issue.exploded"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := service.NewWebhookService(&MockWebhookRepo{})
+
+			_, err := service.CreateWebhook(context.Background(), tt.webhook)
+			if !errors.Is(err, model.ErrInvalidInput) {
+				t.Fatalf("expected invalid input error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestListWebhooks_HidesSecrets(t *testing.T) {
+	mockRepo := &MockWebhookRepo{
+		ListFunc: func(ctx context.Context) ([]*model.Webhook, error) {
+			return []*model.Webhook{{ID: 1, URL: "https://example.com", Secret: "s3cr3t"}}, nil
+		},
+	}
+
+	service := service.NewWebhookService(mockRepo)
+
+	webhooks, err := service.ListWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if webhooks[0].Secret != "" {
+		t.Fatal("expected secret to be hidden")
+	}
+}
+
+func TestWebhookPublish(t *testing.T) {
+	var gotEvent string
+	var gotPayload model.IssueEvent
+	mockRepo := &MockWebhookRepo{
+		EnqueueFunc: func(ctx context.Context, event string, payload []byte) error {
+			gotEvent = event
+			return json.Unmarshal(payload, &gotPayload)
+		},
+	}
+
+	service := service.NewWebhookService(mockRepo)
+
+	event := model.IssueEvent{Type: model.EventIssueDeleted, IssueID: 3, OccurredAt: time.Now()}
+	if err := service.Publish(context.Background(), event); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if gotEvent != model.EventIssueDeleted || gotPayload.IssueID != 3 {
+		t.Fatalf("unexpected delivery %q %+v", gotEvent, gotPayload)
+	}
+}
+
+func TestIssueService_PublishesEvents(t *testing.T) {
+	stored := &model.Issue{ID: 1, Title: "Test", Status: "open", Reporter: "alice"}
+	mockRepo := &MockRepo{
+		CreateFunc: func(ctx context.Context, issue *model.Issue) (int, error) {
+			return 1, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id int) (*model.Issue, error) {
+			issue := *stored
+			return &issue, nil
+		},
+		UpdateFunc: func(ctx context.Context, issue *model.Issue) error {
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id int) error {
+			return nil
+		},
+	}
+	publisher := &RecordingPublisher{}
+
+	service := service.NewIssueService(mockRepo, service.WithEventPublisher(publisher))
+	ctx := auth.WithUser(context.Background(), "bob")
+
+	if _, err := service.CreateIssue(ctx, &model.Issue{Title: "Test"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.UpdateIssue(ctx, &model.Issue{ID: 1, Title: "Test", Status: "open"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.UpdateIssue(ctx, &model.Issue{ID: 1, Title: "Test", Status: "done"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.DeleteIssue(ctx, 1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{
+		model.EventIssueCreated,
+		model.EventIssueUpdated,
+		model.EventIssueUpdated,
+		model.EventIssueStatusChanged,
+		model.EventIssueDeleted,
+	}
+	if got := publisher.types(); !slices.Equal(got, want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+
+	statusChanged := publisher.events[3]
+	if statusChanged.Previous.Status != "open" || statusChanged.Issue.Status != "done" {
+		t.Fatalf("expected previous and new status, got %+v", statusChanged)
+	}
+
+	if statusChanged.Actor != "bob" {
+		t.Fatalf("expected actor bob, got %q", statusChanged.Actor)
+	}
+
+	if statusChanged.Issue.Reporter != "alice" {
+		t.Fatalf("expected reporter to be kept, got %q", statusChanged.Issue.Reporter)
+	}
+}
+
+func TestWebhookService_RequiresAdmin(t *testing.T) {
+	mockRepo := &MockWebhookRepo{
+		GetFunc:     func(ctx context.Context, id int) (*model.Webhook, error) { return &model.Webhook{ID: id}, nil },
+		ListFunc:    func(ctx context.Context) ([]*model.Webhook, error) { return nil, nil },
+		EnqueueFunc: func(ctx context.Context, event string, payload []byte) error { return nil },
+	}
+	members := newMemberService(t, map[string]string{"dave": model.RoleDeveloper}, "root")
+	service := service.NewWebhookService(mockRepo, service.WithWebhookAuthorizer(members))
+	webhook := &model.Webhook{URL: "https://example.com/hook", Events: []string{model.EventIssueCreated}}
+
+	for _, user := range []string{"nobody", "dave"} {
+		ctx := auth.WithUser(context.Background(), user)
+		calls := map[string]error{}
+		_, calls["CreateWebhook"] = service.CreateWebhook(ctx, webhook)
+		_, calls["GetWebhook"] = service.GetWebhook(ctx, 1)
+		calls["UpdateWebhook"] = service.UpdateWebhook(ctx, webhook)
+		calls["DeleteWebhook"] = service.DeleteWebhook(ctx, 1)
+		_, calls["ListWebhooks"] = service.ListWebhooks(ctx)
+		_, calls["ListDeliveries"] = service.ListDeliveries(ctx, 1)
+		_, calls["ListDeliveryAttempts"] = service.ListDeliveryAttempts(ctx, 1, 1)
+		for name, err := range calls {
+			if !errors.Is(err, model.ErrForbidden) {
+				t.Fatalf("%s: expected %s to be forbidden, got %v", user, name, err)
+			}
+		}
+
+		// events of issues changed by anyone are still queued
+		if err := service.Publish(ctx, model.IssueEvent{Type: model.EventIssueCreated, IssueID: 1}); err != nil {
+			t.Fatalf("%s: expected Publish to be allowed, got %v", user, err)
+		}
+	}
+
+	if _, err := service.ListWebhooks(auth.WithUser(context.Background(), "root")); err != nil {
+		t.Fatalf("expected an admin to list webhooks, got %v", err)
+	}
+}
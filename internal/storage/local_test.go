@@ -0,0 +1,69 @@
+package storage_test
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/storage"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create store: %v", err)
+	}
+
+	testBlobStore(t, store)
+}
+
+func TestLocalStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create store: %v", err)
+	}
+
+	err = store.Put(context.Background(), "../escape", strings.NewReader("x"), 1, "text/plain")
+	if err == nil {
+		t.Fatal("expected error for key outside of root")
+	}
+}
+
+type blobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// testBlobStore checks the behaviour shared by all blob store implementations.
+func testBlobStore(t *testing.T, store blobStore) {
+	ctx := context.Background()
+	key := "issues/1/log.txt"
+
+	if err := store.Put(ctx, key, strings.NewReader("hello"), -1, "text/plain"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	content, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, err := io.ReadAll(content)
+	content.Close()
+	if err != nil {
+		t.Fatalf("cannot read blob: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", data)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := store.Get(ctx, key); !errors.Is(err, model.ErrNotFound) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+}
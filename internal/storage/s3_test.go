@@ -0,0 +1,38 @@
+package storage_test
+
+import (
+	"Go-IssueTracker-API/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+// TestS3Store runs against a real S3-compatible server, e.g. the minio service from docker-compose.yml:
+//
+//	S3_TEST_ENDPOINT=localhost:9000 go test ./internal/storage/
+func TestS3Store(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+
+	store, err := storage.NewS3Store(context.Background(), storage.S3Config{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    "issuetracker-test",
+		AccessKey: envOr("S3_TEST_ACCESS_KEY", "minioadmin"),
+		SecretKey: envOr("S3_TEST_SECRET_KEY", "minioadmin"),
+	})
+	if err != nil {
+		t.Fatalf("cannot create store: %v", err)
+	}
+
+	testBlobStore(t, store)
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
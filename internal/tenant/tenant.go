@@ -0,0 +1,71 @@
+// Package tenant keeps the organization a request acts for in the request context.
+// Repositories scope all their data by it.
+package tenant
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Default is used for requests that name no tenant, a single-tenant installation
+// keeps all its data there.
+const Default = "default"
+
+// Header names the tenant of unauthenticated requests, e.g. when authentication is disabled.
+// Authenticated requests always act for the tenant of their credentials.
+const Header = "X-Tenant"
+
+type contextKey struct{}
+
+// With returns a copy of ctx acting for the tenant.
+func With(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+// FromContext returns the tenant stored in ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(contextKey{}).(string)
+	return tenant, ok && tenant != ""
+}
+
+// ID returns the tenant of ctx, or Default when none is set.
+func ID(ctx context.Context) string {
+	if tenant, ok := FromContext(ctx); ok {
+		return tenant
+	}
+	return Default
+}
+
+// Middleware resolves the tenant of a request from the X-Tenant header, see Resolve.
+func Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := Resolve(r.Context(), r.Header.Get(Header))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Resolve returns a copy of ctx acting for the requested tenant. A tenant taken from the
+// credentials by the authentication middleware, which sets Default for credentials without one,
+// wins and a different requested one is rejected.
+// Otherwise requested names the tenant, or Default when it is empty.
+func Resolve(ctx context.Context, requested string) (context.Context, error) {
+	if current, ok := FromContext(ctx); ok {
+		if requested != "" && requested != current {
+			return nil, fmt.Errorf("%w: credentials are not valid for tenant %s", model.ErrForbidden, requested)
+		}
+		return ctx, nil
+	}
+
+	if requested == "" {
+		requested = Default
+	}
+	return With(ctx, requested), nil
+}
@@ -0,0 +1,48 @@
+package tenant_test
+
+import (
+	"Go-IssueTracker-API/internal/tenant"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var got string
+	h := tenant.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = tenant.ID(r.Context())
+	}))
+
+	tests := []struct {
+		name       string
+		credential string
+		header     string
+		wantStatus int
+		wantTenant string
+	}{
+		{"default", "", "", http.StatusOK, tenant.Default},
+		{"header", "", "sales", http.StatusOK, "sales"},
+		{"credential", "finance", "", http.StatusOK, "finance"},
+		{"matching header", "finance", "finance", http.StatusOK, "finance"},
+		{"other tenant", "finance", "sales", http.StatusForbidden, ""},
+	}
+
+	for _, tt := range tests {
+		got = ""
+		req := httptest.NewRequest(http.MethodGet, "/issues", nil)
+		if tt.credential != "" {
+			req = req.WithContext(tenant.With(context.Background(), tt.credential))
+		}
+		if tt.header != "" {
+			req.Header.Set(tenant.Header, tt.header)
+		}
+		res := httptest.NewRecorder()
+
+		h.ServeHTTP(res, req)
+
+		if res.Code != tt.wantStatus || got != tt.wantTenant {
+			t.Fatalf("%s: expected status %d and tenant %q, got %d and %q", tt.name, tt.wantStatus, tt.wantTenant, res.Code, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package tlsconfig builds the TLS configuration of the HTTP server,
+// optionally verifying client certificates (mutual TLS).
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// Client authentication modes.
+const (
+	ClientAuthNone     = "none"     // no client certificate is requested
+	ClientAuthOptional = "optional" // a client certificate is verified if sent
+	ClientAuthRequire  = "require"  // every client must present a valid certificate
+)
+
+type Config struct {
+	CertFile     string // PEM certificate chain
+	KeyFile      string // PEM private key
+	ClientCAFile string // PEM bundle of CAs client certificates must chain to
+	ClientAuth   string // ClientAuthNone, ClientAuthOptional or ClientAuthRequire
+	MinVersion   string // "1.2" or "1.3"
+}
+
+// New returns the server TLS configuration and the reloader serving its certificate.
+func New(cfg Config) (*tls.Config, *Reloader, error) {
+	reloader, err := NewReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load certificate: %w", err)
+	}
+
+	tlsCfg := &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+
+	switch cfg.MinVersion {
+	case "", "1.2":
+	case "1.3":
+		tlsCfg.MinVersion = tls.VersionTLS13
+	default:
+		return nil, nil, fmt.Errorf("unsupported minimum TLS version %q", cfg.MinVersion)
+	}
+
+	switch cfg.ClientAuth {
+	case "", ClientAuthNone:
+		if cfg.ClientCAFile != "" {
+			return nil, nil, fmt.Errorf("client CA given but client authentication is %q", ClientAuthNone)
+		}
+		return tlsCfg, reloader, nil
+	case ClientAuthOptional:
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, nil, fmt.Errorf("unknown client authentication %q", cfg.ClientAuth)
+	}
+
+	if cfg.ClientCAFile == "" {
+		return nil, nil, fmt.Errorf("client authentication %q needs a client CA", cfg.ClientAuth)
+	}
+	pem, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load client CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, nil, fmt.Errorf("load client CA: no certificates in %s", cfg.ClientCAFile)
+	}
+	tlsCfg.ClientCAs = pool
+
+	return tlsCfg, reloader, nil
+}
@@ -0,0 +1,205 @@
+package tlsconfig_test
+
+import (
+	"Go-IssueTracker-API/internal/tlsconfig"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type keyPair struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// issue creates a certificate for name, signed by parent or self-signed when parent is nil.
+func issue(t *testing.T, name string, parent *keyPair, isCA bool) *keyPair {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return &keyPair{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (p *keyPair) write(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeFile(t, certFile, p.certPEM)
+	writeFile(t, keyFile, p.keyPEM)
+	return certFile, keyFile
+}
+
+func TestNew_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := issue(t, "test CA", nil, true)
+	server := issue(t, "server", ca, false)
+	client := issue(t, "client", ca, false)
+	stranger := issue(t, "stranger", nil, false)
+
+	certFile, keyFile := server.write(t, dir)
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, caFile, ca.certPEM)
+
+	serverTLS, _, err := tlsconfig.New(tlsconfig.Config{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+		ClientAuth:   tlsconfig.ClientAuthRequire,
+		MinVersion:   "1.2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// served like main does, with the certificate only from GetCertificate
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{
+		Handler:   http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		ErrorLog:  log.New(io.Discard, "", 0),
+		TLSConfig: serverTLS,
+	}
+	go srv.ServeTLS(ln, "", "")
+	defer srv.Close()
+	url := "https://" + ln.Addr().String()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	get := func(pair *keyPair) error {
+		clientTLS := &tls.Config{RootCAs: roots}
+		if pair != nil {
+			clientTLS.Certificates = []tls.Certificate{{Certificate: [][]byte{pair.cert.Raw}, PrivateKey: pair.key}}
+		}
+		c := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+		res, err := c.Get(url)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		return nil
+	}
+
+	if err := get(client); err != nil {
+		t.Fatalf("client signed by the CA must be accepted: %v", err)
+	}
+	if err := get(nil); err == nil {
+		t.Fatal("client without certificate must be rejected")
+	}
+	if err := get(stranger); err == nil {
+		t.Fatal("client certificate of another CA must be rejected")
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := issue(t, "server", nil, false).write(t, dir)
+
+	tests := []struct {
+		name string
+		cfg  tlsconfig.Config
+	}{
+		{"missing key", tlsconfig.Config{CertFile: certFile, KeyFile: filepath.Join(dir, "nope")}},
+		{"client auth without CA", tlsconfig.Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: tlsconfig.ClientAuthRequire}},
+		{"CA without client auth", tlsconfig.Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile}},
+		{"CA file without certificates", tlsconfig.Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: tlsconfig.ClientAuthOptional, ClientCAFile: keyFile}},
+		{"old TLS version", tlsconfig.Config{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tlsconfig.New(tt.cfg); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestReloader_PicksUpNewCertificate(t *testing.T) {
+	dir := t.TempDir()
+	first := issue(t, "first", nil, false)
+	certFile, keyFile := first.write(t, dir)
+
+	r, err := tlsconfig.NewReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reloaded, err := r.Reload(); err != nil || reloaded {
+		t.Fatalf("unchanged files must not be reloaded: %v, %v", reloaded, err)
+	}
+
+	second := issue(t, "second", nil, false)
+	second.write(t, dir)
+	if reloaded, err := r.Reload(); err != nil || !reloaded {
+		t.Fatalf("expected a reload: %v, %v", reloaded, err)
+	}
+	cert, _ := r.GetCertificate(nil)
+	if leaf, _ := x509.ParseCertificate(cert.Certificate[0]); leaf.Subject.CommonName != "second" {
+		t.Fatalf("expected the new certificate, got %q", leaf.Subject.CommonName)
+	}
+
+	// a half-written pair keeps the last good certificate
+	writeFile(t, keyFile, first.keyPEM)
+	if _, err := r.Reload(); err == nil {
+		t.Fatal("expected an error for a mismatched pair")
+	}
+	cert, _ = r.GetCertificate(nil)
+	if leaf, _ := x509.ParseCertificate(cert.Certificate[0]); leaf.Subject.CommonName != "second" {
+		t.Fatalf("expected the previous certificate to stay, got %q", leaf.Subject.CommonName)
+	}
+}
@@ -0,0 +1,135 @@
+package tracing_test
+
+import (
+	"Go-IssueTracker-API/internal/handler"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/service"
+	"Go-IssueTracker-API/internal/tracing"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// provider is installed once: package level tracers keep delegating to the first global provider.
+var provider = sdktrace.NewTracerProvider()
+
+func init() {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider.RegisterSpanProcessor(recorder)
+	t.Cleanup(func() { provider.UnregisterSpanProcessor(recorder) })
+	return recorder
+}
+
+type issueRepo struct{}
+
+func (issueRepo) CreateIssue(context.Context, *model.Issue) (int, error) { return 1, nil }
+func (issueRepo) UpdateIssue(context.Context, *model.Issue) error        { return nil }
+func (issueRepo) DeleteIssue(context.Context, int) error                 { return nil }
+func (issueRepo) ListIssues(context.Context, model.IssueFilter) ([]*model.Issue, error) {
+	return nil, nil
+}
+func (issueRepo) CountIssues(context.Context, model.IssueFilter) (int, error) { return 0, nil }
+func (issueRepo) GetIssueByID(context.Context, int) (*model.Issue, error) {
+	return nil, model.ErrNotFound
+}
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	recorder := recordSpans(t)
+
+	h := handler.NewHandler(service.NewIssueService(issueRepo{}))
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Get("/issues/{id}", h.GetIssueByID)
+
+	req := httptest.NewRequest(http.MethodGet, "/issues/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", res.Code)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	// spans end innermost first
+	svc, hdl, srv := spans[0], spans[1], spans[2]
+	if srv.Name() != "GET /issues/{id}" || hdl.Name() != "Handler.GetIssueByID" || svc.Name() != "IssueService.GetIssueByID" {
+		t.Fatalf("unexpected span names %q, %q, %q", srv.Name(), hdl.Name(), svc.Name())
+	}
+	for _, span := range spans {
+		if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Fatalf("span %q not in the incoming trace: %s", span.Name(), got)
+		}
+	}
+	if srv.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Fatalf("server span should be a child of the caller, parent %s", srv.Parent().SpanID())
+	}
+	if hdl.Parent().SpanID() != srv.SpanContext().SpanID() || svc.Parent().SpanID() != hdl.SpanContext().SpanID() {
+		t.Fatal("expected server -> handler -> service nesting")
+	}
+	if svc.Status().Code != codes.Error {
+		t.Fatalf("expected the service span to record the error, got %v", svc.Status())
+	}
+}
+
+func TestMiddleware_StartsNewTrace(t *testing.T) {
+	recorder := recordSpans(t)
+
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Get("/boom", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Parent().IsValid() {
+		t.Fatal("expected a root span without traceparent")
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Fatalf("expected error status for 500, got %v", spans[0].Status())
+	}
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "op")
+	err := errors.New("boom")
+	tracing.End(span, &err)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Status().Description != "boom" || len(spans[0].Events()) != 1 {
+		t.Fatalf("expected the error to be recorded on the span, got %+v", spans)
+	}
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	if _, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"}); err == nil {
+		t.Fatal("expected an error for an unknown exporter")
+	}
+}
@@ -0,0 +1,53 @@
+// Package version reports what build of the server is running.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version and Commit are set at build time:
+//
+//	go build -ldflags "-X Go-IssueTracker-API/internal/version.Version=v1.2.0 -X Go-IssueTracker-API/internal/version.Commit=$(git rev-parse HEAD)"
+//
+// Without them Commit falls back to the VCS information embedded by the go command.
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"` // commit time, RFC 3339
+	Modified  bool   `json:"modified,omitempty"`   // built from a tree with uncommitted changes
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			info.BuildTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,201 @@
+// Package webhook sends queued webhook deliveries to their subscribers.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Go-IssueTracker-API/internal/logging"
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+)
+
+const (
+	EventHeader     = "X-IssueTracker-Event"
+	DeliveryHeader  = "X-IssueTracker-Delivery"
+	SignatureHeader = "X-IssueTracker-Signature"
+)
+
+// Sign returns the value of the signature header for the payload:
+// "sha256=" followed by the hex encoded HMAC-SHA256 of the payload keyed with the webhook secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is a valid signature of the payload.
+// Receivers written in Go can use it to check incoming requests.
+func Verify(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
+
+type Store interface {
+	GetWebhook(ctx context.Context, id int) (*model.Webhook, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error)
+	RecordDeliveryAttempt(ctx context.Context, attempt *model.DeliveryAttempt, status string, nextAttemptAt time.Time) error
+}
+
+type Config struct {
+	PollInterval time.Duration // how often the queue is checked for due deliveries
+	BatchSize    int           // deliveries claimed per poll
+	Timeout      time.Duration // timeout of a single HTTP request
+	MaxAttempts  int           // attempts before a delivery is marked as failed
+	BaseBackoff  time.Duration // delay before the first retry, doubled for every next one
+	MaxBackoff   time.Duration
+}
+
+// Worker polls the delivery queue and sends due deliveries,
+// rescheduling failed ones with exponential backoff.
+type Worker struct {
+	store  Store
+	client *http.Client
+	cfg    Config
+}
+
+func NewWorker(store Store, cfg Config) *Worker {
+	return &Worker{
+		store:  store,
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+	}
+}
+
+// Run processes the queue until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+			logging.FromContext(ctx).Error("webhook worker failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue sends one batch of due deliveries.
+func (w *Worker) ProcessDue(ctx context.Context) error {
+	// a claimed delivery is retried by another worker if this one dies before recording the attempt;
+	// the lease covers sending the whole batch one after another, each request taking up to the timeout
+	lease := time.Duration(w.cfg.BatchSize)*w.cfg.Timeout + w.cfg.PollInterval
+	deadline := time.Now().Add(lease)
+
+	deliveries, err := w.store.ClaimDueDeliveries(ctx, w.cfg.BatchSize, lease)
+	if err != nil {
+		return err
+	}
+
+	// one failing delivery must not strand the rest of the batch until their lease expires
+	var errs []error
+	for _, delivery := range deliveries {
+		// slow recording can eat into the lease; what could not finish before it ends
+		// is left to whichever worker claims it next rather than sent twice
+		if time.Until(deadline) < w.cfg.Timeout {
+			break
+		}
+		if err := w.deliver(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("delivery %d: %w", delivery.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (w *Worker) deliver(ctx context.Context, delivery *model.WebhookDelivery) error {
+	// the worker serves all tenants, the webhook is looked up in the one of the delivery
+	webhook, err := w.store.GetWebhook(tenant.With(ctx, delivery.Tenant), delivery.WebhookID)
+	if err != nil {
+		attempt := &model.DeliveryAttempt{
+			DeliveryID:  delivery.ID,
+			AttemptedAt: time.Now().UTC(),
+			Error:       fmt.Sprintf("cannot load webhook: %v", err),
+		}
+		// a deleted webhook will not come back, other errors are retried like failed requests
+		if errors.Is(err, model.ErrNotFound) {
+			return w.store.RecordDeliveryAttempt(ctx, attempt, model.DeliveryFailed, attempt.AttemptedAt)
+		}
+		return w.record(ctx, delivery, attempt)
+	}
+
+	return w.record(ctx, delivery, w.send(ctx, webhook, delivery))
+}
+
+// record stores the attempt, rescheduling the delivery with backoff if it failed.
+func (w *Worker) record(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.DeliveryAttempt) error {
+	status := model.DeliveryDelivered
+	next := attempt.AttemptedAt
+	if attempt.Error != "" {
+		attempts := delivery.Attempts + 1
+		if attempts >= w.cfg.MaxAttempts {
+			status = model.DeliveryFailed
+		} else {
+			status = model.DeliveryPending
+			next = attempt.AttemptedAt.Add(Backoff(attempts, w.cfg.BaseBackoff, w.cfg.MaxBackoff))
+		}
+	}
+
+	return w.store.RecordDeliveryAttempt(ctx, attempt, status, next)
+}
+
+func (w *Worker) send(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery) *model.DeliveryAttempt {
+	attempt := &model.DeliveryAttempt{
+		DeliveryID:  delivery.ID,
+		AttemptedAt: time.Now().UTC(),
+	}
+	defer func() {
+		attempt.DurationMS = time.Since(attempt.AttemptedAt).Milliseconds()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Go-IssueTracker-Webhook")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	attempt.StatusCode = res.StatusCode
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status %s", res.Status)
+	}
+
+	return attempt
+}
+
+// Backoff returns the delay before the next try after the given number of failed attempts.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return min(delay, max)
+}
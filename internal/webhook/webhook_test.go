@@ -0,0 +1,219 @@
+package webhook_test
+
+import (
+	"Go-IssueTracker-API/internal/model"
+	"Go-IssueTracker-API/internal/tenant"
+	"Go-IssueTracker-API/internal/webhook"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type recordedAttempt struct {
+	attempt *model.DeliveryAttempt
+	status  string
+	next    time.Time
+}
+
+type MockStore struct {
+	webhook    *model.Webhook
+	deliveries []*model.WebhookDelivery
+	recorded   []recordedAttempt
+	tenant     string        // tenant the webhook was looked up in
+	lease      time.Duration // of the last claim
+}
+
+func (m *MockStore) GetWebhook(ctx context.Context, id int) (*model.Webhook, error) {
+	m.tenant = tenant.ID(ctx)
+	if m.webhook == nil || m.webhook.ID != id {
+		return nil, model.ErrNotFound
+	}
+	return m.webhook, nil
+}
+
+func (m *MockStore) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	deliveries := m.deliveries
+	m.deliveries = nil
+	m.lease = lease
+	return deliveries, nil
+}
+
+func (m *MockStore) RecordDeliveryAttempt(ctx context.Context, attempt *model.DeliveryAttempt, status string, nextAttemptAt time.Time) error {
+	m.recorded = append(m.recorded, recordedAttempt{attempt, status, nextAttemptAt})
+	return nil
+}
+
+func testConfig() webhook.Config {
+	return webhook.Config{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		Timeout:      time.Second,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Minute,
+		MaxBackoff:   time.Hour,
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{"type":"issue.created"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=78ba6048cd92eca21fc7fdff38dc43bb5577613783c3d63ce2c7dd2a31c5c8e5"
+	payload := []byte(`{"type":"issue.created"}`)
+
+	got := webhook.Sign("secret", payload)
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	if !webhook.Verify("secret", payload, got) {
+		t.Fatal("expected signature to verify")
+	}
+
+	if webhook.Verify("other", payload, got) {
+		t.Fatal("expected signature with another secret not to verify")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{10, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := webhook.Backoff(tt.attempts, time.Minute, time.Hour); got != tt.want {
+			t.Fatalf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWorker_Delivered(t *testing.T) {
+	payload := `{"type":"issue.created","issue_id":1}`
+
+	var gotSignature, gotEvent, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotSignature = r.Header.Get(webhook.SignatureHeader)
+		gotEvent = r.Header.Get(webhook.EventHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := &MockStore{
+		webhook: &model.Webhook{ID: 1, URL: server.URL, Secret: "s3cr3t"},
+		deliveries: []*model.WebhookDelivery{
+			{ID: 10, WebhookID: 1, Tenant: "finance", Event: model.EventIssueCreated, Payload: []byte(payload)},
+		},
+	}
+
+	if err := webhook.NewWorker(store, testConfig()).ProcessDue(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if store.tenant != "finance" {
+		t.Fatalf("expected webhook to be looked up in tenant finance, got %q", store.tenant)
+	}
+
+	if gotBody != payload || gotEvent != model.EventIssueCreated {
+		t.Fatalf("unexpected request: event %q body %q", gotEvent, gotBody)
+	}
+
+	if !webhook.Verify("s3cr3t", []byte(payload), gotSignature) {
+		t.Fatalf("invalid signature %q", gotSignature)
+	}
+
+	if len(store.recorded) != 1 || store.recorded[0].status != model.DeliveryDelivered {
+		t.Fatalf("expected delivered attempt, got %+v", store.recorded)
+	}
+
+	if store.recorded[0].attempt.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected status code to be recorded, got %d", store.recorded[0].attempt.StatusCode)
+	}
+}
+
+func TestWorker_RetryAndFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := &MockStore{
+		webhook: &model.Webhook{ID: 1, URL: server.URL, Secret: "s3cr3t"},
+		deliveries: []*model.WebhookDelivery{
+			{ID: 10, WebhookID: 1, Payload: []byte(`{}`), Attempts: 0},
+			{ID: 11, WebhookID: 1, Payload: []byte(`{}`), Attempts: 2},
+		},
+	}
+
+	if err := webhook.NewWorker(store, testConfig()).ProcessDue(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(store.recorded) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(store.recorded))
+	}
+
+	retry := store.recorded[0]
+	if retry.status != model.DeliveryPending || retry.attempt.Error == "" {
+		t.Fatalf("expected pending delivery with error, got %+v", retry)
+	}
+	if delay := retry.next.Sub(retry.attempt.AttemptedAt); delay != time.Minute {
+		t.Fatalf("expected retry after 1m, got %v", delay)
+	}
+
+	if failed := store.recorded[1]; failed.status != model.DeliveryFailed {
+		t.Fatalf("expected failed delivery after max attempts, got %q", failed.status)
+	}
+}
+
+func TestWorker_MissingWebhookDoesNotStopBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := &MockStore{
+		webhook: &model.Webhook{ID: 1, URL: server.URL, Secret: "s3cr3t"},
+		deliveries: []*model.WebhookDelivery{
+			{ID: 10, WebhookID: 2, Payload: []byte(`{}`)},
+			{ID: 11, WebhookID: 1, Payload: []byte(`{}`)},
+		},
+	}
+
+	if err := webhook.NewWorker(store, testConfig()).ProcessDue(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(store.recorded) != 2 {
+		t.Fatalf("expected both deliveries to be recorded, got %+v", store.recorded)
+	}
+	if missing := store.recorded[0]; missing.status != model.DeliveryFailed || missing.attempt.Error == "" {
+		t.Fatalf("expected the delivery of the deleted webhook to fail, got %+v", missing)
+	}
+	if delivered := store.recorded[1]; delivered.status != model.DeliveryDelivered {
+		t.Fatalf("expected the next delivery to be sent, got %+v", delivered)
+	}
+}
+
+func TestWorker_LeaseCoversBatch(t *testing.T) {
+	store := &MockStore{}
+	cfg := testConfig()
+	worker := webhook.NewWorker(store, cfg)
+
+	if err := worker.ProcessDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the deliveries of a batch are sent one after another, each may take the whole timeout
+	if min := time.Duration(cfg.BatchSize) * cfg.Timeout; store.lease < min {
+		t.Fatalf("expected a lease of at least %s, got %s", min, store.lease)
+	}
+}
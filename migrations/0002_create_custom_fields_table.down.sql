@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS issues_custom_fields_idx;
+
+ALTER TABLE issues DROP COLUMN IF EXISTS custom_fields;
+
+DROP TABLE IF EXISTS custom_fields;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS issue_watchers;
+
+ALTER TABLE issues DROP COLUMN IF EXISTS assignee;
+ALTER TABLE issues DROP COLUMN IF EXISTS reporter;
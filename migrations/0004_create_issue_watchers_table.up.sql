@@ -0,0 +1,11 @@
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS reporter TEXT NOT NULL DEFAULT '';
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS assignee TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS issue_watchers (
+    issue_id INTEGER NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issue_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS issue_watchers_user_id_idx ON issue_watchers (user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS email_notifications;
+DROP TABLE IF EXISTS issue_email_subscriptions;
@@ -0,0 +1,24 @@
+-- no foreign key to issues: subscribers must still be notified when an issue is deleted
+CREATE TABLE IF NOT EXISTS issue_email_subscriptions (
+    id SERIAL PRIMARY KEY,
+    issue_id INTEGER NOT NULL,
+    email TEXT NOT NULL,
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (issue_id, email)
+);
+
+CREATE TABLE IF NOT EXISTS email_notifications (
+    id SERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    issue_id INTEGER NOT NULL,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    digest BOOLEAN NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS email_notifications_unsent_idx ON email_notifications (email, created_at) WHERE sent_at IS NULL;
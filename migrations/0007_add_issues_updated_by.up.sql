@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS updated_by TEXT NOT NULL DEFAULT '';
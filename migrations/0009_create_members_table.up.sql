@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS members (
+    user_id TEXT PRIMARY KEY,
+    role TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
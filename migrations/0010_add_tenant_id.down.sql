@@ -0,0 +1,21 @@
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_pkey;
+DELETE FROM members a USING members b WHERE a.user_id = b.user_id AND a.tenant_id > b.tenant_id;
+ALTER TABLE members ADD PRIMARY KEY (user_id);
+ALTER TABLE custom_fields DROP CONSTRAINT IF EXISTS custom_fields_tenant_id_name_key;
+DELETE FROM custom_fields a USING custom_fields b WHERE a.name = b.name AND a.id > b.id;
+ALTER TABLE custom_fields ADD CONSTRAINT custom_fields_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS webhooks_tenant_id_idx;
+DROP INDEX IF EXISTS issues_tenant_id_idx;
+
+ALTER TABLE members DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE email_notifications DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE issue_email_subscriptions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhook_delivery_attempts DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE issue_watchers DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE attachments DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE custom_fields DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE issues DROP COLUMN IF EXISTS tenant_id;
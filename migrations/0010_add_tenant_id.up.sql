@@ -0,0 +1,32 @@
+-- existing rows belong to the default tenant, new rows must name their tenant
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE issues ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE custom_fields ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE custom_fields ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE attachments ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE issue_watchers ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE issue_watchers ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_deliveries ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhook_delivery_attempts ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_delivery_attempts ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE issue_email_subscriptions ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE issue_email_subscriptions ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE email_notifications ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE email_notifications ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE api_tokens ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE members ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE members ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS issues_tenant_id_idx ON issues (tenant_id);
+CREATE INDEX IF NOT EXISTS webhooks_tenant_id_idx ON webhooks (tenant_id);
+
+-- custom field names and memberships are unique per tenant
+ALTER TABLE custom_fields DROP CONSTRAINT IF EXISTS custom_fields_name_key;
+ALTER TABLE custom_fields ADD CONSTRAINT custom_fields_tenant_id_name_key UNIQUE (tenant_id, name);
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_pkey;
+ALTER TABLE members ADD PRIMARY KEY (tenant_id, user_id);
@@ -0,0 +1 @@
+ALTER TABLE email_notifications DROP COLUMN IF EXISTS next_attempt_at;
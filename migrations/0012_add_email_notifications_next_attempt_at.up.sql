@@ -0,0 +1,2 @@
+-- failed emails are retried with backoff instead of on every poll
+ALTER TABLE email_notifications ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW();